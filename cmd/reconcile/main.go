@@ -1,131 +1,459 @@
 package main
 
 import (
+	"errors"
 	"flag"
 	"fmt"
+	"io"
 	"os"
+	"path/filepath"
 	"strings"
 	"time"
 
 	"github.com/farhaan/amartha-reconcile-system/internal/domain"
+	"github.com/farhaan/amartha-reconcile-system/internal/domain/statement"
 	"github.com/farhaan/amartha-reconcile-system/internal/domain/transaction"
 	"github.com/farhaan/amartha-reconcile-system/internal/infrastructure/csv"
+	"github.com/farhaan/amartha-reconcile-system/internal/infrastructure/fxrates"
+	"github.com/farhaan/amartha-reconcile-system/internal/infrastructure/input"
 	"github.com/farhaan/amartha-reconcile-system/pkg/matcher"
+	"github.com/farhaan/amartha-reconcile-system/pkg/report"
+)
+
+// Exit codes, so a scheduler can tell a data problem from a reconciliation that found differences
+const (
+	exitOK          = 0 // Everything reconciled
+	exitError       = 1 // Invalid flags or configuration, or reconciliation failed
+	exitDataProblem = 2 // No usable input files, or a file broke the validation policy
+	exitDifferences = 3 // Reconciliation ran and found unmatched transactions or discrepancies
 )
 
 func main() {
 	// CLI flags
 	systemFiles := flag.String("system", "", "Comma-separated paths to system transactions CSV file (required)")
-	bankFiles := flag.String("banks", "", "Comma-separated paths to bank statement CSV files (required)")
+	bankFiles := flag.String("banks", "", "Comma-separated paths to bank statement files: CSV, XLSX, camt.053/camt.054 XML or MT940 (required unless -bank or -bank-manifest is given)")
 	startDate := flag.String("start", "", "Start date for reconciliation (YYYY-MM-DD, required)")
 	endDate := flag.String("end", "", "End date for reconciliation (YYYY-MM-DD, required)")
+	matcherName := flag.String("matcher", "exact", "Matching algorithm: exact, date_window, tolerance, assignment, split")
+	matchSource := flag.Bool("match-source", false, "Only match bank lines from the system transaction's source bank, report cross-bank near-misses")
+	maxGroupSize := flag.Int("max-group-size", matcher.DefaultConfig().MaxGroupSize, "Max transactions combined into one grouped match (split matcher)")
+	dateWindow := flag.Int("date-window", matcher.DefaultConfig().DateWindowDays, "Max days between system and bank dates (date_window matcher)")
+	tolerancePct := flag.Float64("tolerance-pct", matcher.DefaultConfig().AmountTolerancePct, "Allowed amount difference in percent (tolerance matcher)")
+	toleranceAbs := flag.String("tolerance-abs", "0", "Cap on allowed amount difference, 0 = no cap (tolerance matcher)")
+	fxRatesFile := flag.String("fx-rates", "", "Path to an FX rate table (CSV or JSON) for matching foreign currency bank lines")
+	fxTolerancePct := flag.Float64("fx-tolerance-pct", matcher.DefaultConfig().FXTolerancePct, "Allowed difference in percent after FX conversion")
+	bankProfilesFile := flag.String("bank-profiles", "", "Path to a JSON file of per-bank column profiles for non-standard statement layouts")
+	sheetName := flag.String("sheet", "", "Worksheet to read from .xlsx inputs (default: first sheet)")
+	rejectsFile := flag.String("rejects", "", "Write rows that could not be read to this file (.json for JSON, otherwise CSV)")
+	bankManifest := flag.String("bank-manifest", "", "Path to a JSON manifest assigning bank statement files to banks")
+	var bankAssignments bankFlags
+	flag.Var(&bankAssignments, "bank", "Bank statement file with its bank, as BANK=path (repeatable)")
+	var bankPatterns patternFlags
+	flag.Var(&bankPatterns, "bank-pattern", "Regular expression whose first or \"bank\" group captures the bank from a filename (repeatable)")
+	strict := flag.Bool("strict", false, "Fail the run on any rejected row, empty file or unreadable file")
+	maxRejectPct := flag.Float64("max-reject-pct", csv.DefaultValidationPolicy().MaxRejectPct, "Fail the run when a file has more than this percentage of rejected rows")
+	failOnEmpty := flag.Bool("fail-on-empty", false, "Fail the run when a file has no valid rows")
+	reportFormat := flag.String("format", "text", "Report format: "+strings.Join(report.Formats(), ", "))
+	outFile := flag.String("out", "", "Write the report to this file instead of stdout")
+	exceptionsDir := flag.String("exceptions-dir", "", "Write matched.csv, unmatched_system.csv, unmatched_bank.csv and discrepancies.csv to this directory")
+	exceptionsXLSX := flag.String("exceptions-xlsx", "", "Write the exception reports as sheets of this .xlsx workbook")
 	flag.Parse()
 
 	// Validate required flags
-	if *systemFiles == "" || *bankFiles == "" || *startDate == "" || *endDate == "" {
+	hasBankFiles := *bankFiles != "" || len(bankAssignments) > 0 || *bankManifest != ""
+	if *systemFiles == "" || !hasBankFiles || *startDate == "" || *endDate == "" {
 		fmt.Println("Error: Missing required flags")
 		flag.Usage()
-		os.Exit(1)
+		os.Exit(exitError)
+	}
+
+	renderer, err := report.NewRenderer(*reportFormat)
+	if err != nil {
+		fmt.Printf("Error: %v\n", err)
+		os.Exit(exitError)
+	}
+
+	// Any report but text on stdout must be the only thing there, so progress goes to stderr
+	var progress io.Writer = os.Stdout
+	if *reportFormat != "text" && *outFile == "" {
+		progress = os.Stderr
+	}
+
+	// Validation policy for the input files
+	policy := csv.DefaultValidationPolicy()
+	if *strict {
+		policy = csv.StrictValidationPolicy()
+	}
+	policy.MaxRejectPct = *maxRejectPct
+	policy.FailOnEmpty = policy.FailOnEmpty || *failOnEmpty
+	if err := policy.Validate(); err != nil {
+		fmt.Fprintf(progress, "Error: %v\n", err)
+		os.Exit(exitError)
 	}
 
 	// Parse dates
 	start, err := time.Parse("2006-01-02", *startDate)
 	if err != nil {
-		fmt.Printf("Error: Invalid start date format: %v\n", err)
-		os.Exit(1)
+		fmt.Fprintf(progress, "Error: Invalid start date format: %v\n", err)
+		os.Exit(exitError)
 	}
 
 	end, err := time.Parse("2006-01-02", *endDate)
 	if err != nil {
-		fmt.Printf("Error: Invalid end date format: %v\n", err)
-		os.Exit(1)
+		fmt.Fprintf(progress, "Error: Invalid end date format: %v\n", err)
+		os.Exit(exitError)
 	}
 
-	// Parse bank files
-	bankFilePaths := strings.Split(*bankFiles, ",")
-	for i, path := range bankFilePaths {
-		bankFilePaths[i] = strings.TrimSpace(path)
+	// Parse bank files, and the banks they belong to
+	sources := input.NewSourceResolver()
+	bankFilePaths := make([]string, 0)
+	if *bankFiles != "" {
+		for _, path := range strings.Split(*bankFiles, ",") {
+			bankFilePaths = append(bankFilePaths, strings.TrimSpace(path))
+		}
+	}
+	for _, assignment := range bankAssignments {
+		sources.SetFile(assignment.path, assignment.bank)
+		bankFilePaths = append(bankFilePaths, assignment.path)
+	}
+	if *bankManifest != "" {
+		manifestFiles, err := sources.LoadManifest(*bankManifest)
+		if err != nil {
+			fmt.Fprintf(progress, "Error: %v\n", err)
+			os.Exit(exitError)
+		}
+		bankFilePaths = append(bankFilePaths, manifestFiles...)
+	}
+	for _, pattern := range bankPatterns {
+		if err := sources.AddPattern(pattern); err != nil {
+			fmt.Fprintf(progress, "Error: %v\n", err)
+			os.Exit(exitError)
+		}
+	}
+	// A file named by -banks and again by -bank or the manifest is read once, under its explicit bank
+	validBankFilePaths, invalidBankFilePaths := testPathValidity(uniquePaths(bankFilePaths))
+	if len(invalidBankFilePaths) > 0 {
+		fmt.Fprintf(progress, "Invalid bank file paths: %+v\n", invalidBankFilePaths)
 	}
-	validBankFilePaths, invalidBankFilePaths := testPathValidity(bankFilePaths)
-	fmt.Printf("Invalid bank file paths: %+v\n", invalidBankFilePaths)
 
 	systemFilePaths := strings.Split(*systemFiles, ",")
 	for i, path := range systemFilePaths {
 		systemFilePaths[i] = strings.TrimSpace(path)
 	}
 	validSystemFilePaths, invalidSystemFilePaths := testPathValidity(systemFilePaths)
-	fmt.Printf("Invalid system file paths: %+v\n", invalidSystemFilePaths)
+	if len(invalidSystemFilePaths) > 0 {
+		fmt.Fprintf(progress, "Invalid system file paths: %+v\n", invalidSystemFilePaths)
+	}
 
 	if len(validBankFilePaths) == 0 {
-		fmt.Println("Error: No valid bank statement files provided")
-		panic("No valid bank statement files provided")
+		fmt.Fprintln(progress, "Error: No valid bank statement files provided")
+		os.Exit(exitDataProblem)
 	}
 	if len(validSystemFilePaths) == 0 {
-		fmt.Println("Error: No valid system transaction files provided")
-		panic("No valid system transaction files provided")
+		fmt.Fprintln(progress, "Error: No valid system transaction files provided")
+		os.Exit(exitDataProblem)
 	}
 
-	fmt.Println("---------------------------------------------------------")
-	fmt.Println("Amartha Transaction Reconciliation System")
+	// Files that could not be used, listed in the report apart from the ones that were read
+	failedFiles := append(append([]string{}, invalidSystemFilePaths...), invalidBankFilePaths...)
+
+	// Files that break the validation policy, reported together once every file is read
+	dataProblems := make([]string, 0)
+	if policy.FailOnFileError {
+		for _, path := range append(invalidSystemFilePaths, invalidBankFilePaths...) {
+			dataProblems = append(dataProblems, fmt.Sprintf("%s: file not found", path))
+		}
+	}
+
+	fmt.Fprintln(progress, "---------------------------------------------------------")
+	fmt.Fprintln(progress, "Amartha Transaction Reconciliation System")
+
+	// Files are read by the format detected from their content
+	registry := input.Default()
+	opts := input.Options{JobID: "cli-job", Sheet: *sheetName, Sources: sources}
+
+	// Rows left out of any file, for the rejects report
+	rejects := make([]input.Reject, 0)
 
 	// Read system transactions
 	systemTxns := make([]*transaction.Transaction, 0)
 	systemCounts := make(map[string]int)
+	loadedSystemFiles := make([]string, 0, len(validSystemFilePaths))
 
 	for _, systemFile := range validSystemFilePaths {
-		txns, err := readSystemTransactions(systemFile, start, end)
+		txns, fileRejects, err := readSystemTransactions(progress, registry, systemFile, opts, policy, start, end)
+		rejects = append(rejects, fileRejects...)
 		if err != nil {
-			fmt.Printf("Error reading %s: %v\n", systemFile, err)
+			fmt.Fprintf(progress, "Error reading %s: %v\n", systemFile, err)
+			failedFiles = append(failedFiles, systemFile)
+			if errors.Is(err, csv.ErrValidation) || policy.FailOnFileError {
+				dataProblems = append(dataProblems, fmt.Sprintf("%s: %v", systemFile, err))
+			}
 			continue
 		}
 
 		// Count by system file (for reporting)
 		if len(txns) > 0 {
 			systemCounts[systemFile] = len(txns)
-			fmt.Printf("%s: %d transactions\n", systemFile, len(txns))
+			fmt.Fprintf(progress, "%s: %d transactions\n", systemFile, len(txns))
 		}
 		systemTxns = append(systemTxns, txns...)
+		loadedSystemFiles = append(loadedSystemFiles, systemFile)
 	}
 
-	fmt.Printf("Loaded %d system transactions\n\n", len(systemTxns))
+	fmt.Fprintf(progress, "Loaded %d system transactions\n\n", len(systemTxns))
+
+	// Load column profiles for banks with their own export layout
+	if *bankProfilesFile != "" {
+		opts.Profiles, err = csv.LoadProfiles(*bankProfilesFile)
+		if err != nil {
+			fmt.Fprintf(progress, "Error: %v\n", err)
+			os.Exit(exitError)
+		}
+	}
+	for bank := range opts.Profiles {
+		sources.AddKnownBank(bank)
+	}
 
 	// Read bank statements
-	fmt.Println("Reading bank statements...")
+	fmt.Fprintln(progress, "Reading bank statements...")
 	bankTxns := make([]*transaction.Transaction, 0)
-	bankCounts := make(map[string]int)
+	statements := make([]*statement.Statement, 0)
+	loadedBankFiles := make([]string, 0, len(validBankFilePaths))
 
 	for _, bankFile := range validBankFilePaths {
-		txns, err := readBankStatements(bankFile, start, end)
+		txns, stmts, fileRejects, err := readBankStatements(progress, registry, bankFile, opts, policy, start, end)
+		rejects = append(rejects, fileRejects...)
 		if err != nil {
-			fmt.Printf("Error reading %s: %v\n", bankFile, err)
+			fmt.Fprintf(progress, "Error reading %s: %v\n", bankFile, err)
+			failedFiles = append(failedFiles, bankFile)
+			if errors.Is(err, csv.ErrValidation) || policy.FailOnFileError {
+				dataProblems = append(dataProblems, fmt.Sprintf("%s: %v", bankFile, err))
+			}
+			if errors.Is(err, input.ErrUnknownSource) {
+				fmt.Fprintln(progress, "Name its bank with -bank BANK=path, a -bank-manifest entry, a -bank-pattern, or a source column")
+			}
 			continue
 		}
+		statements = append(statements, stmts...)
 
-		// Count by bank source
-		if len(txns) > 0 {
-			source := txns[0].Source
-			bankCounts[source] = len(txns)
-			fmt.Printf("%s: %d transactions\n", source, len(txns))
+		// Count by bank source, per file; the report totals them per bank
+		fileCounts := make(map[string]int)
+		for _, txn := range txns {
+			fileCounts[txn.Source]++
+		}
+		for source, count := range fileCounts {
+			fmt.Fprintf(progress, "%s: %d transactions\n", source, count)
+		}
+
+		for _, stmt := range stmts {
+			if stmt.AccountNumber != "" {
+				fmt.Fprintf(progress, "%s: account %s\n", stmt.Source, stmt.AccountNumber)
+			}
+			if stmt.Check.Status == statement.BalanceMismatch {
+				fmt.Fprintf(progress, "WARNING: %s fails balance check (%s), matches against it should not be trusted\n",
+					bankFile, stmt.Check.Reason)
+			}
+			if len(stmt.BalanceBreaks) > 0 {
+				fmt.Fprintf(progress, "WARNING: %s running balance breaks at %d rows, a line may be missing or duplicated\n",
+					bankFile, len(stmt.BalanceBreaks))
+			}
 		}
 
 		bankTxns = append(bankTxns, txns...)
+		loadedBankFiles = append(loadedBankFiles, bankFile)
+	}
+	fmt.Fprintf(progress, "Total bank transactions: %d\n\n", len(bankTxns))
+
+	// Reconciling files that broke the policy would only produce misleading differences
+	if len(dataProblems) > 0 {
+		fmt.Fprintln(progress, "Error: Input files failed validation, reconciliation was not run")
+		for _, problem := range dataProblems {
+			fmt.Fprintf(progress, "  %s\n", problem)
+		}
+		writeRejects(progress, *rejectsFile, rejects)
+		os.Exit(exitDataProblem)
 	}
-	fmt.Printf("Total bank transactions: %d\n\n", len(bankTxns))
 
-	m := matcher.NewExactMatcher(matcher.DefaultConfig())
+	// With every file of one side unreadable, everything on the other side would show as a difference
+	if len(loadedBankFiles) == 0 {
+		fmt.Fprintln(progress, "Error: No bank statement file could be read, reconciliation was not run")
+		writeRejects(progress, *rejectsFile, rejects)
+		os.Exit(exitDataProblem)
+	}
+	if len(loadedSystemFiles) == 0 {
+		fmt.Fprintln(progress, "Error: No system transaction file could be read, reconciliation was not run")
+		writeRejects(progress, *rejectsFile, rejects)
+		os.Exit(exitDataProblem)
+	}
+
+	config := matcher.DefaultConfig()
+	config.MatchSource = *matchSource
+	config.MaxGroupSize = *maxGroupSize
+	config.DateWindowDays = *dateWindow
+	config.AmountTolerancePct = *tolerancePct
+	config.AmountToleranceAbs, err = domain.ParseMoney(*toleranceAbs, domain.DefaultCurrency)
+	if err != nil {
+		fmt.Fprintf(progress, "Error: Invalid tolerance cap: %v\n", err)
+		os.Exit(exitError)
+	}
+	config.FXTolerancePct = *fxTolerancePct
+	if *fxRatesFile != "" {
+		config.FXRates, err = fxrates.LoadRateTable(*fxRatesFile)
+		if err != nil {
+			fmt.Fprintf(progress, "Error: %v\n", err)
+			os.Exit(exitError)
+		}
+	}
+	m, err := matcher.NewMatcher(*matcherName, config)
+	if err != nil {
+		fmt.Fprintf(progress, "Error: %v\n", err)
+		os.Exit(exitError)
+	}
 
 	// Perform reconciliation
-	fmt.Println("Reconciling transactions...")
+	fmt.Fprintln(progress, "Reconciling transactions...")
 	result, err := m.Match(systemTxns, bankTxns)
 	if err != nil {
-		fmt.Printf("Error during reconciliation: %v\n", err)
-		os.Exit(1)
+		fmt.Fprintf(progress, "Error during reconciliation: %v\n", err)
+		os.Exit(exitError)
 	}
-	fmt.Println("Reconciliation complete")
-	fmt.Println()
+	fmt.Fprintln(progress, "Reconciliation complete")
+	fmt.Fprintln(progress)
 
 	// Print report
-	printReconciliationReport(result, bankCounts, start, end)
+	run := &report.Run{
+		Start:       start,
+		End:         end,
+		GeneratedAt: time.Now().UTC(),
+		SystemFiles: loadedSystemFiles,
+		BankFiles:   loadedBankFiles,
+		FailedFiles: failedFiles,
+		Statements:  statements,
+		Rejects:     rejects,
+	}
+	if err := writeReport(os.Stdout, progress, *outFile, renderer, result, run); err != nil {
+		fmt.Fprintf(progress, "Error: %v\n", err)
+		os.Exit(exitError)
+	}
+
+	writeRejects(progress, *rejectsFile, rejects)
+
+	// Exception files for working through the differences in a spreadsheet
+	if *exceptionsDir != "" {
+		if err := report.WriteExceptionCSVs(*exceptionsDir, result); err != nil {
+			fmt.Fprintf(progress, "Error: %v\n", err)
+			os.Exit(exitError)
+		}
+		fmt.Fprintf(progress, "Exception reports written to %s\n", *exceptionsDir)
+	}
+	if *exceptionsXLSX != "" {
+		if err := report.WriteExceptionWorkbook(*exceptionsXLSX, result); err != nil {
+			fmt.Fprintf(progress, "Error: %v\n", err)
+			os.Exit(exitError)
+		}
+		fmt.Fprintf(progress, "Exception workbook written to %s\n", *exceptionsXLSX)
+	}
+
+	if hasDifferences(result) {
+		os.Exit(exitDifferences)
+	}
+	os.Exit(exitOK)
+}
+
+// writeReport renders the report to filePath, or to w when no file is given.
+// Progress messages go to progress.
+func writeReport(w, progress io.Writer, filePath string, renderer report.Renderer, result *matcher.MatchResult, run *report.Run) error {
+	if filePath == "" {
+		return renderer.Render(w, result, run)
+	}
+
+	file, err := os.Create(filePath)
+	if err != nil {
+		return fmt.Errorf("failed to create report %s: %w", filePath, err)
+	}
+	if err := renderer.Render(file, result, run); err != nil {
+		file.Close()
+		return err
+	}
+	if err := file.Close(); err != nil {
+		return fmt.Errorf("failed to write report %s: %w", filePath, err)
+	}
+
+	fmt.Fprintf(progress, "Report written to %s\n", filePath)
+	return nil
+}
+
+// writeRejects writes the rejected rows to filePath, if one was given
+func writeRejects(progress io.Writer, filePath string, rejects []input.Reject) {
+	if filePath == "" {
+		return
+	}
+	if err := input.WriteRejects(filePath, rejects); err != nil {
+		fmt.Fprintf(progress, "Error: %v\n", err)
+		os.Exit(exitError)
+	}
+	fmt.Fprintf(progress, "Rejected rows written to %s\n", filePath)
+}
+
+// hasDifferences reports whether the reconciliation left anything unmatched or unequal
+func hasDifferences(result *matcher.MatchResult) bool {
+	return len(result.UnmatchedSystem) > 0 || len(result.UnmatchedBank) > 0 ||
+		!result.TotalDiscrepancy.IsZero() || len(result.UnconvertedDiscrepancy) > 0
+}
+
+// bankFlag is one -bank BANK=path flag
+type bankFlag struct {
+	bank string
+	path string
+}
+
+// bankFlags collects repeated -bank flags
+type bankFlags []bankFlag
+
+func (f *bankFlags) String() string {
+	values := make([]string, len(*f))
+	for i, assignment := range *f {
+		values[i] = assignment.bank + "=" + assignment.path
+	}
+	return strings.Join(values, ",")
+}
+
+func (f *bankFlags) Set(value string) error {
+	bank, path, ok := strings.Cut(value, "=")
+	bank, path = strings.TrimSpace(bank), strings.TrimSpace(path)
+	if !ok || bank == "" || path == "" {
+		return fmt.Errorf("expected BANK=path, got %q", value)
+	}
+	*f = append(*f, bankFlag{bank: bank, path: path})
+	return nil
+}
+
+// patternFlags collects repeated -bank-pattern flags
+type patternFlags []string
+
+func (f *patternFlags) String() string {
+	return strings.Join(*f, ",")
+}
+
+func (f *patternFlags) Set(value string) error {
+	*f = append(*f, value)
+	return nil
+}
+
+// uniquePaths returns the paths in order, cleaned, with repeats of a path left out
+func uniquePaths(paths []string) []string {
+	seen := make(map[string]bool, len(paths))
+	unique := make([]string, 0, len(paths))
+	for _, path := range paths {
+		path = filepath.Clean(path)
+		if seen[path] {
+			continue
+		}
+		seen[path] = true
+		unique = append(unique, path)
+	}
+	return unique
 }
 
 func testPathValidity(paths []string) (validPaths []string, invalidPaths []string) {
@@ -149,175 +477,80 @@ func isValidPath(path string) bool {
 	return !info.IsDir()
 }
 
-func readSystemTransactions(filePath string, start, end time.Time) ([]*transaction.Transaction, error) {
-	reader, err := csv.NewReader(filePath)
+// readSystemTransactions reads a system transactions file in whichever format it is in
+// and returns the transactions within the period, and the rows that could not be read.
+// A file that breaks the policy returns an error wrapping csv.ErrValidation with its rejects.
+func readSystemTransactions(progress io.Writer, registry *input.Registry, filePath string, opts input.Options, policy csv.ValidationPolicy, start, end time.Time) ([]*transaction.Transaction, []input.Reject, error) {
+	format, err := registry.Detect(filePath)
 	if err != nil {
-		return nil, err
+		return nil, nil, err
 	}
 
-	txns := make([]*transaction.Transaction, 0)
-	errorCount := 0
-
-	err = reader.ReadSystemTransactions(func(row *csv.SystemTransactionRow, rowErr error) error {
-		if rowErr != nil {
-			errorCount++
-			return nil // Continue processing
-		}
-
-		txn, err := csv.ParseSystemTransaction(row, "cli-job", "system-file")
-		if err != nil {
-			errorCount++
-			return nil // Continue processing
-		}
-
-		// Filter by date range
-		if txn.TransactionDate.Before(start) || txn.TransactionDate.After(end) {
-			return nil // Skip
-		}
-
-		txns = append(txns, txn)
-		return nil
-	})
+	opts.FileID = "system-file"
+	file, err := format.ReadSystem(filePath, opts)
+	if err != nil {
+		return nil, nil, err
+	}
 
-	if errorCount > 0 {
-		fmt.Printf("Skipped %d invalid rows\n", errorCount)
+	if len(file.Rejects) > 0 {
+		fmt.Fprintf(progress, "Skipped %d invalid rows\n", len(file.Rejects))
+	}
+	if err := policy.Check(len(file.Transactions), len(file.Rejects)); err != nil {
+		return nil, file.Rejects, err
 	}
 
-	return txns, err
+	return filterPeriod(file.Transactions, start, end), file.Rejects, nil
 }
 
-func readBankStatements(filePath string, start, end time.Time) ([]*transaction.Transaction, error) {
-	// Extract bank source from filename
-	bankSource, err := csv.ExtractBankSourceFromFilename(filePath)
+// readBankStatements reads a bank file in whichever format it is in. Each statement in it
+// is balance checked against all of its transactions; only those within the period are
+// returned for matching, with the rows that could not be read. The policy applies to the
+// file as a whole.
+func readBankStatements(progress io.Writer, registry *input.Registry, filePath string, opts input.Options, policy csv.ValidationPolicy, start, end time.Time) ([]*transaction.Transaction, []*statement.Statement, []input.Reject, error) {
+	format, err := registry.Detect(filePath)
 	if err != nil {
-		return nil, fmt.Errorf("could not extract bank source from filename: %w", err)
+		return nil, nil, nil, err
 	}
 
-	reader, err := csv.NewReader(filePath)
+	opts.FileID = "bank-file"
+	bankStatements, err := format.ReadBank(filePath, opts)
 	if err != nil {
-		return nil, err
+		return nil, nil, nil, err
 	}
 
 	txns := make([]*transaction.Transaction, 0)
-	errorCount := 0
-
-	err = reader.ReadBankStatements(func(row *csv.BankStatementRow, rowErr error) error {
-		if rowErr != nil {
-			errorCount++
-			return nil // Continue processing
-		}
-
-		txn, err := csv.ParseBankTransaction(row, "cli-job", "bank-file", bankSource)
-		if err != nil {
-			errorCount++
-			return nil // Continue processing
+	stmts := make([]*statement.Statement, 0, len(bankStatements))
+	rejects := make([]input.Reject, 0)
+	valid := 0
+	for _, bankStatement := range bankStatements {
+		if len(bankStatement.Rejects) > 0 {
+			fmt.Fprintf(progress, "%s: Skipped %d invalid rows\n", bankStatement.Statement.Source, len(bankStatement.Rejects))
 		}
-
-		// Filter by date range
-		if txn.TransactionDate.Before(start) || txn.TransactionDate.After(end) {
-			return nil // Skip
-		}
-
-		txns = append(txns, txn)
-		return nil
-	})
-
-	if errorCount > 0 {
-		fmt.Printf("%s: Skipped %d invalid rows\n", bankSource, errorCount)
-	}
-
-	return txns, err
-}
-
-func printReconciliationReport(result *matcher.MatchResult, bankCounts map[string]int, start, end time.Time) {
-	fmt.Println("RECONCILIATION REPORT")
-
-	// Period
-	fmt.Printf("Reconciliation Period: %s to %s\n", start.Format("2006-01-02"), end.Format("2006-01-02"))
-	fmt.Println()
-	// Summary
-	fmt.Println("SUMMARY")
-	fmt.Println("---------------------------------------------------------")
-	fmt.Printf("Total Transactions Processed:   %d\n", result.TotalSystemTxns+result.TotalBankTxns)
-	fmt.Printf("System transactions:            %d\n", result.TotalSystemTxns)
-	fmt.Printf("Bank transactions:              %d\n", result.TotalBankTxns)
-	fmt.Printf("Matched Transactions:           %d (%.1f%%)\n", result.TotalMatched, result.MatchRate)
-	fmt.Printf("Unmatched Transactions:         %d\n", len(result.UnmatchedSystem)+len(result.UnmatchedBank))
-	fmt.Printf("Unmatched system:               %d\n", len(result.UnmatchedSystem))
-	fmt.Printf("Unmatched bank:                 %d\n", len(result.UnmatchedBank))
-	fmt.Printf("Total Discrepancy Amount:       %.2f\n", result.TotalDiscrepancy)
-
-	// Matched transactions with discrepancies
-	if len(result.Matched) > 0 {
-		hasDiscrepancies := false
-		for _, match := range result.Matched {
-			if match.AmountDiscrepancy > 0.001 {
-				hasDiscrepancies = true
-				break
-			}
+		for _, warning := range bankStatement.Warnings {
+			fmt.Fprintf(progress, "%s: %s\n", bankStatement.Statement.Source, warning)
 		}
 
-		if hasDiscrepancies {
-			fmt.Println("MATCHED TRANSACTIONS WITH DISCREPANCIES")
-			fmt.Println("---------------------------------------------------------")
-			for _, match := range result.Matched {
-				if match.AmountDiscrepancy > 0.001 {
-					fmt.Printf("System: %s (%.2f) ↔ Bank: %s (%.2f) | Discrepancy: %.2f\n",
-						match.SystemTransaction.ID,
-						match.SystemTransaction.AbsAmount(),
-						match.BankTransaction.ID,
-						match.BankTransaction.AbsAmount(),
-						match.AmountDiscrepancy)
-				}
-			}
-			fmt.Println()
-		}
+		txns = append(txns, filterPeriod(bankStatement.Transactions, start, end)...)
+		stmts = append(stmts, bankStatement.Statement)
+		rejects = append(rejects, bankStatement.Rejects...)
+		valid += len(bankStatement.Transactions)
 	}
 
-	fmt.Println()
-
-	// Unmatched system transactions
-	if len(result.UnmatchedSystem) > 0 {
-		fmt.Println("UNMATCHED SYSTEM TRANSACTIONS")
-		fmt.Println("---------------------------------------------------------")
-		fmt.Println("Transactions in system but missing in bank statement(s):")
-		fmt.Println()
-
-		for _, txn := range result.UnmatchedSystem {
-			typeStr := "CREDIT"
-			if txn.Type == domain.TransactionTypeDebit {
-				typeStr = "DEBIT"
-			}
-			fmt.Printf("ID: %-15s | Source: %-10s | Type: %-6s | Amount: %10.2f | Date: %s\n",
-				txn.ID, txn.Source, typeStr, txn.AbsAmount(), txn.TransactionDate.Format("2006-01-02"))
-		}
-		fmt.Println()
+	if err := policy.Check(valid, len(rejects)); err != nil {
+		return nil, nil, rejects, err
 	}
 
-	// Unmatched bank transactions (grouped by bank)
-	if len(result.UnmatchedBank) > 0 {
-		fmt.Println("UNMATCHED BANK TRANSACTIONS")
-		fmt.Println("---------------------------------------------------------")
-		fmt.Println("Transactions in bank statement(s) but missing in system:")
-		fmt.Println()
-
-		// Group by bank source
-		byBank := make(map[string][]*transaction.Transaction)
-		for _, txn := range result.UnmatchedBank {
-			byBank[txn.Source] = append(byBank[txn.Source], txn)
-		}
+	return txns, stmts, rejects, nil
+}
 
-		for bank, txns := range byBank {
-			fmt.Printf("%s (%d transactions):\n", bank, len(txns))
-			for _, txn := range txns {
-				typeStr := "CREDIT"
-				if txn.Type == domain.TransactionTypeDebit {
-					typeStr = "DEBIT"
-				}
-				fmt.Printf("ID: %-15s | Type: %-6s | Amount: %10.2f | Date: %s\n",
-					txn.ID, typeStr, txn.AbsAmount(), txn.TransactionDate.Format("2006-01-02"))
-			}
-			fmt.Println()
+// filterPeriod returns the transactions dated within the reconciliation period
+func filterPeriod(txns []*transaction.Transaction, start, end time.Time) []*transaction.Transaction {
+	filtered := make([]*transaction.Transaction, 0, len(txns))
+	for _, txn := range txns {
+		if txn.TransactionDate.Before(start) || txn.TransactionDate.After(end) {
+			continue // Skip
 		}
+		filtered = append(filtered, txn)
 	}
+	return filtered
 }
@@ -0,0 +1,74 @@
+package main
+
+import (
+	"errors"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"reflect"
+	"testing"
+)
+
+// TestMain runs the CLI instead of the tests when a test starts this binary with
+// RECONCILE_RUN_MAIN set, so tests can check its exit code
+func TestMain(m *testing.M) {
+	if os.Getenv("RECONCILE_RUN_MAIN") == "1" {
+		os.Args = append([]string{"reconcile"}, os.Args[2:]...)
+		main()
+		return
+	}
+	os.Exit(m.Run())
+}
+
+// runCLI runs the CLI with the given flags and returns its exit code and output
+func runCLI(t *testing.T, args ...string) (int, string) {
+	t.Helper()
+	cmd := exec.Command(os.Args[0], append([]string{"-test.run=^$"}, args...)...)
+	cmd.Env = append(os.Environ(), "RECONCILE_RUN_MAIN=1")
+	out, err := cmd.CombinedOutput()
+
+	var exitErr *exec.ExitError
+	if errors.As(err, &exitErr) {
+		return exitErr.ExitCode(), string(out)
+	}
+	if err != nil {
+		t.Fatalf("failed to run the CLI: %v", err)
+	}
+	return exitOK, string(out)
+}
+
+func TestUniquePaths(t *testing.T) {
+	paths := []string{"fixtures/bca.csv", "fixtures/mandiri.csv", "./fixtures/bca.csv", "fixtures//mandiri.csv"}
+
+	got := uniquePaths(paths)
+	expected := []string{"fixtures/bca.csv", "fixtures/mandiri.csv"}
+	if !reflect.DeepEqual(got, expected) {
+		t.Errorf("Expected %v, got %v", expected, got)
+	}
+}
+
+func TestMain_NoFileLoaded(t *testing.T) {
+	unreadable := filepath.Join(t.TempDir(), "system.csv")
+	if err := os.WriteFile(unreadable, []byte("not,a,system,file\n"), 0644); err != nil {
+		t.Fatalf("failed to write %s: %v", unreadable, err)
+	}
+
+	tests := []struct {
+		name   string
+		system string
+		banks  string
+	}{
+		// The combined statement names no bank, so it cannot be read without -bank
+		{"no bank file", "../../fixtures/system_transactions.csv", "../../fixtures/combined_statement_ambiguous_2024-03-15.csv"},
+		{"no system file", unreadable, "../../fixtures/bca_statement_2024-03-15.csv"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			code, out := runCLI(t, "-system", tt.system, "-banks", tt.banks, "-start", "2024-03-01", "-end", "2024-03-31")
+			if code != exitDataProblem {
+				t.Errorf("Expected exit code %d, got %d:\n%s", exitDataProblem, code, out)
+			}
+		})
+	}
+}
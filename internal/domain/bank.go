@@ -0,0 +1,36 @@
+package domain
+
+import "strings"
+
+// bankCodes maps the bank code of a BIC (its first four characters) to the bank
+// name used as a transaction source
+var bankCodes = map[string]string{
+	"CENA": "BCA",
+	"BMRI": "MANDIRI",
+	"BNIN": "BNI",
+	"BRIN": "BRI",
+	"BBBA": "PERMATA",
+	"BNIA": "CIMB",
+	"DBSB": "DBS",
+}
+
+// BankFromBIC returns the source name of a bank from its BIC, e.g. CENAIDJA is BCA
+func BankFromBIC(bic string) (string, bool) {
+	bic = strings.ToUpper(strings.TrimSpace(bic))
+	if len(bic) < 4 {
+		return "", false
+	}
+	name, ok := bankCodes[bic[:4]]
+	return name, ok
+}
+
+// IsKnownBank reports whether name is one of the banks in the BIC list
+func IsKnownBank(name string) bool {
+	name = strings.ToUpper(strings.TrimSpace(name))
+	for _, bank := range bankCodes {
+		if bank == name {
+			return true
+		}
+	}
+	return false
+}
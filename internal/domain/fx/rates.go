@@ -0,0 +1,159 @@
+package fx
+
+import (
+	"fmt"
+	"math/big"
+	"sort"
+	"time"
+
+	"github.com/farhaan/amartha-reconcile-system/internal/domain"
+)
+
+// pair identifies a conversion direction, e.g. USD -> IDR
+type pair struct {
+	From domain.Currency
+	To   domain.Currency
+}
+
+// datedRate is a rate valid from a given day until the next dated rate for the same pair
+type datedRate struct {
+	Date time.Time
+	Rate *big.Rat
+}
+
+// RateTable holds exchange rates keyed by date and currency pair
+type RateTable struct {
+	rates map[pair][]datedRate
+}
+
+// NewRateTable creates an empty rate table
+func NewRateTable() *RateTable {
+	return &RateTable{
+		rates: make(map[pair][]datedRate),
+	}
+}
+
+// AddRate records that one unit of from is worth rate units of to on the given day.
+// The rate is a decimal string (e.g. "15650.25") so it is stored exactly.
+func (rt *RateTable) AddRate(date time.Time, from, to domain.Currency, rate string) error {
+	r, ok := new(big.Rat).SetString(rate)
+	if !ok {
+		return fmt.Errorf("invalid rate %q", rate)
+	}
+	if r.Sign() <= 0 {
+		return fmt.Errorf("rate must be positive, got %q", rate)
+	}
+	if from == to {
+		return fmt.Errorf("rate from %s to itself", from)
+	}
+
+	key := pair{From: from, To: to}
+	rates := append(rt.rates[key], datedRate{Date: truncateDay(date), Rate: r})
+	sort.SliceStable(rates, func(i, j int) bool {
+		return rates[i].Date.Before(rates[j].Date)
+	})
+	rt.rates[key] = rates
+	return nil
+}
+
+// Rate returns the rate for converting from into to on the given day. The most recent
+// rate on or before that day is used, so weekends and holidays fall back to the last
+// published rate. An inverse rate (to -> from) is used when no direct rate exists.
+func (rt *RateTable) Rate(date time.Time, from, to domain.Currency) (*big.Rat, error) {
+	if from == to {
+		return big.NewRat(1, 1), nil
+	}
+
+	day := truncateDay(date)
+	if r := latestOnOrBefore(rt.rates[pair{From: from, To: to}], day); r != nil {
+		return r, nil
+	}
+	if r := latestOnOrBefore(rt.rates[pair{From: to, To: from}], day); r != nil {
+		return new(big.Rat).Inv(r), nil
+	}
+	return nil, fmt.Errorf("no %s/%s rate on or before %s", from, to, day.Format("2006-01-02"))
+}
+
+// Convert converts an amount into another currency using the rate for the given day.
+// The result is rounded with the target currency's rounding rule.
+func (rt *RateTable) Convert(amount domain.Money, to domain.Currency, date time.Time) (domain.Money, error) {
+	from := amount.Currency
+	if from == "" {
+		from = domain.DefaultCurrency
+	}
+	if from == to {
+		return amount, nil
+	}
+
+	fromRule, err := domain.RuleFor(from)
+	if err != nil {
+		return domain.Money{}, err
+	}
+	toRule, err := domain.RuleFor(to)
+	if err != nil {
+		return domain.Money{}, err
+	}
+
+	rate, err := rt.Rate(date, from, to)
+	if err != nil {
+		return domain.Money{}, err
+	}
+
+	// minor_to = minor_from / 10^exp_from * rate * 10^exp_to
+	value := new(big.Rat).SetInt64(amount.Minor)
+	value.Mul(value, rate)
+	value.Mul(value, new(big.Rat).SetFrac(pow10(toRule.Exponent), pow10(fromRule.Exponent)))
+
+	minor, err := roundRat(value, toRule.Rounding)
+	if err != nil {
+		return domain.Money{}, fmt.Errorf("converting %s %s to %s: %w", amount, from, to, err)
+	}
+	return domain.NewMoney(minor, to), nil
+}
+
+// latestOnOrBefore returns the rate of the last entry dated on or before day
+func latestOnOrBefore(rates []datedRate, day time.Time) *big.Rat {
+	var found *big.Rat
+	for _, r := range rates {
+		if r.Date.After(day) {
+			break
+		}
+		found = r.Rate
+	}
+	return found
+}
+
+// roundRat rounds a rational number to an int64 with the given rounding mode
+func roundRat(value *big.Rat, mode domain.RoundingMode) (int64, error) {
+	num := new(big.Int).Abs(value.Num())
+	den := value.Denom()
+
+	quo, rem := new(big.Int).QuoRem(num, den, new(big.Int))
+	twiceRem := new(big.Int).Mul(rem, big.NewInt(2))
+
+	switch twiceRem.Cmp(den) {
+	case 1:
+		quo.Add(quo, big.NewInt(1))
+	case 0:
+		if mode == domain.RoundHalfUp || quo.Bit(0) == 1 {
+			quo.Add(quo, big.NewInt(1))
+		}
+	}
+
+	if value.Sign() < 0 {
+		quo.Neg(quo)
+	}
+	if !quo.IsInt64() {
+		return 0, fmt.Errorf("amount out of range")
+	}
+	return quo.Int64(), nil
+}
+
+func pow10(n int) *big.Int {
+	return new(big.Int).Exp(big.NewInt(10), big.NewInt(int64(n)), nil)
+}
+
+func truncateDay(t time.Time) time.Time {
+	y, m, d := t.Date()
+	return time.Date(y, m, d, 0, 0, 0, 0, time.UTC)
+}
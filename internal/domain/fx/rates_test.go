@@ -0,0 +1,97 @@
+package fx
+
+import (
+	"testing"
+	"time"
+
+	"github.com/farhaan/amartha-reconcile-system/internal/domain"
+)
+
+func TestRateTable_Convert(t *testing.T) {
+	table := NewRateTable()
+	friday := time.Date(2024, 3, 15, 0, 0, 0, 0, time.UTC)
+	monday := time.Date(2024, 3, 18, 0, 0, 0, 0, time.UTC)
+
+	if err := table.AddRate(friday, domain.CurrencyUSD, domain.CurrencyIDR, "15650.25"); err != nil {
+		t.Fatalf("AddRate failed: %v", err)
+	}
+	if err := table.AddRate(monday, domain.CurrencyUSD, domain.CurrencyIDR, "15610.50"); err != nil {
+		t.Fatalf("AddRate failed: %v", err)
+	}
+
+	tests := []struct {
+		name string
+		date time.Time
+		want int64
+	}{
+		{"same day", friday, 156502500},
+		{"weekend uses last published rate", friday.AddDate(0, 0, 1), 156502500},
+		{"newer rate", monday, 156105000},
+	}
+
+	for _, tt := range tests {
+		got, err := table.Convert(domain.NewMoney(10000, domain.CurrencyUSD), domain.CurrencyIDR, tt.date)
+		if err != nil {
+			t.Errorf("%s: Convert failed: %v", tt.name, err)
+			continue
+		}
+		if got.Minor != tt.want || got.Currency != domain.CurrencyIDR {
+			t.Errorf("%s: got %s %s, want %d IDR minor units", tt.name, got, got.Currency, tt.want)
+		}
+	}
+
+	if _, err := table.Convert(domain.NewMoney(10000, domain.CurrencyUSD), domain.CurrencyIDR, friday.AddDate(0, 0, -1)); err == nil {
+		t.Error("Expected error for a date before the first rate")
+	}
+}
+
+func TestRateTable_InverseRate(t *testing.T) {
+	table := NewRateTable()
+	date := time.Date(2024, 3, 15, 0, 0, 0, 0, time.UTC)
+
+	if err := table.AddRate(date, domain.CurrencyUSD, domain.CurrencyIDR, "16000"); err != nil {
+		t.Fatalf("AddRate failed: %v", err)
+	}
+
+	// 1,000,000 IDR / 16,000 = 62.50 USD
+	got, err := table.Convert(domain.NewMoney(100000000, domain.CurrencyIDR), domain.CurrencyUSD, date)
+	if err != nil {
+		t.Fatalf("Convert failed: %v", err)
+	}
+	if got.Minor != 6250 {
+		t.Errorf("Expected 62.50 USD, got %s", got)
+	}
+}
+
+func TestRateTable_RoundsPerTargetCurrency(t *testing.T) {
+	table := NewRateTable()
+	date := time.Date(2024, 3, 15, 0, 0, 0, 0, time.UTC)
+
+	if err := table.AddRate(date, domain.CurrencyIDR, domain.CurrencyUSD, "0.0000625"); err != nil {
+		t.Fatalf("AddRate failed: %v", err)
+	}
+
+	// 1,000 IDR is exactly 0.0625 USD: half even keeps 0.06
+	got, err := table.Convert(domain.NewMoney(100000, domain.CurrencyIDR), domain.CurrencyUSD, date)
+	if err != nil {
+		t.Fatalf("Convert failed: %v", err)
+	}
+	if got.Minor != 6 {
+		t.Errorf("Expected 0.06 USD (half even), got %s", got)
+	}
+}
+
+func TestRateTable_AddRate_Invalid(t *testing.T) {
+	table := NewRateTable()
+	date := time.Date(2024, 3, 15, 0, 0, 0, 0, time.UTC)
+
+	if err := table.AddRate(date, domain.CurrencyUSD, domain.CurrencyIDR, "abc"); err == nil {
+		t.Error("Expected error for non-numeric rate")
+	}
+	if err := table.AddRate(date, domain.CurrencyUSD, domain.CurrencyIDR, "-1"); err == nil {
+		t.Error("Expected error for negative rate")
+	}
+	if err := table.AddRate(date, domain.CurrencyIDR, domain.CurrencyIDR, "1"); err == nil {
+		t.Error("Expected error for same-currency rate")
+	}
+}
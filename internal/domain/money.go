@@ -0,0 +1,264 @@
+package domain
+
+import (
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+)
+
+// Currency is an ISO 4217 currency code
+type Currency string
+
+const (
+	CurrencyIDR Currency = "IDR"
+	CurrencyUSD Currency = "USD"
+	CurrencySGD Currency = "SGD"
+	CurrencyEUR Currency = "EUR"
+	CurrencyJPY Currency = "JPY"
+)
+
+// DefaultCurrency is used when a file does not say which currency its amounts are in
+const DefaultCurrency = CurrencyIDR
+
+// RoundingMode decides what happens to digits beyond a currency's minor unit
+type RoundingMode int
+
+const (
+	// RoundHalfUp rounds ties away from zero (0.125 -> 0.13)
+	RoundHalfUp RoundingMode = iota
+	// RoundHalfEven rounds ties to the nearest even digit (0.125 -> 0.12)
+	RoundHalfEven
+)
+
+// CurrencyRule describes how amounts in a currency are stored and rounded
+type CurrencyRule struct {
+	Exponent int // Number of minor unit digits, e.g. 2 for cents
+	Rounding RoundingMode
+}
+
+// currencyRules holds the rounding rules per supported currency
+var currencyRules = map[Currency]CurrencyRule{
+	CurrencyIDR: {Exponent: 2, Rounding: RoundHalfUp},
+	CurrencyUSD: {Exponent: 2, Rounding: RoundHalfEven},
+	CurrencySGD: {Exponent: 2, Rounding: RoundHalfEven},
+	CurrencyEUR: {Exponent: 2, Rounding: RoundHalfEven},
+	CurrencyJPY: {Exponent: 0, Rounding: RoundHalfUp},
+}
+
+// RuleFor returns the rounding rule for a currency. An empty currency uses DefaultCurrency.
+func RuleFor(currency Currency) (CurrencyRule, error) {
+	if currency == "" {
+		currency = DefaultCurrency
+	}
+	rule, ok := currencyRules[currency]
+	if !ok {
+		return CurrencyRule{}, fmt.Errorf("unsupported currency %q", currency)
+	}
+	return rule, nil
+}
+
+// Money is an exact amount in integer minor units (e.g. cents) of a currency.
+// The zero value is zero in no particular currency and can be added to any Money.
+type Money struct {
+	Minor    int64
+	Currency Currency
+}
+
+// NewMoney creates Money from an amount already in minor units
+func NewMoney(minor int64, currency Currency) Money {
+	return Money{Minor: minor, Currency: currency}
+}
+
+// ParseMoney parses a plain decimal string such as "-150.50" into Money.
+// Digits beyond the currency's minor unit are rounded with the currency's rounding mode.
+func ParseMoney(s string, currency Currency) (Money, error) {
+	rule, err := RuleFor(currency)
+	if err != nil {
+		return Money{}, err
+	}
+	if currency == "" {
+		currency = DefaultCurrency
+	}
+
+	str := strings.TrimSpace(s)
+	negative := false
+	if strings.HasPrefix(str, "-") || strings.HasPrefix(str, "+") {
+		negative = str[0] == '-'
+		str = str[1:]
+	}
+
+	intPart, fracPart, _ := strings.Cut(str, ".")
+	if intPart == "" && fracPart == "" {
+		return Money{}, fmt.Errorf("invalid amount %q", s)
+	}
+	if !isDigits(intPart) || !isDigits(fracPart) {
+		return Money{}, fmt.Errorf("invalid amount %q", s)
+	}
+
+	kept := fracPart
+	dropped := ""
+	if len(fracPart) > rule.Exponent {
+		kept, dropped = fracPart[:rule.Exponent], fracPart[rule.Exponent:]
+	}
+	kept += strings.Repeat("0", rule.Exponent-len(kept))
+
+	minor, err := parseMinorDigits(intPart + kept)
+	if err != nil {
+		return Money{}, fmt.Errorf("invalid amount %q: %w", s, err)
+	}
+
+	if roundsUp(minor, dropped, rule.Rounding) {
+		if minor == math.MaxInt64 {
+			return Money{}, fmt.Errorf("invalid amount %q: out of range", s)
+		}
+		minor++
+	}
+
+	if negative {
+		minor = -minor
+	}
+	return Money{Minor: minor, Currency: currency}, nil
+}
+
+// MoneyFromFloat converts a float amount in major units (e.g. a config value) to Money
+func MoneyFromFloat(amount float64, currency Currency) (Money, error) {
+	return ParseMoney(strconv.FormatFloat(amount, 'f', -1, 64), currency)
+}
+
+// IsZero returns true if the amount is zero
+func (m Money) IsZero() bool {
+	return m.Minor == 0
+}
+
+// IsNegative returns true if the amount is below zero
+func (m Money) IsNegative() bool {
+	return m.Minor < 0
+}
+
+// Neg returns the amount with its sign flipped
+func (m Money) Neg() Money {
+	return Money{Minor: -m.Minor, Currency: m.Currency}
+}
+
+// Abs returns the absolute value of the amount
+func (m Money) Abs() Money {
+	if m.Minor < 0 {
+		return m.Neg()
+	}
+	return m
+}
+
+// Add returns m + other. Both must be in the same currency unless one is the zero value.
+func (m Money) Add(other Money) Money {
+	currency := m.mustShareCurrency(other)
+	return Money{Minor: m.Minor + other.Minor, Currency: currency}
+}
+
+// Sub returns m - other. Both must be in the same currency unless one is the zero value.
+func (m Money) Sub(other Money) Money {
+	return m.Add(other.Neg())
+}
+
+// Equal returns true if both amounts and currencies are the same
+func (m Money) Equal(other Money) bool {
+	return m.Minor == other.Minor && (m.Currency == other.Currency || m.Minor == 0)
+}
+
+// Cmp compares amounts in the same currency, returning -1, 0 or +1
+func (m Money) Cmp(other Money) int {
+	m.mustShareCurrency(other)
+	switch {
+	case m.Minor < other.Minor:
+		return -1
+	case m.Minor > other.Minor:
+		return 1
+	default:
+		return 0
+	}
+}
+
+// Percent returns pct percent of the amount, rounded with the currency's rounding mode
+func (m Money) Percent(pct float64) Money {
+	rule, _ := RuleFor(m.Currency)
+	value := float64(m.Minor) * pct / 100.0
+	rounded := math.Round(value)
+	if rule.Rounding == RoundHalfEven {
+		rounded = math.RoundToEven(value)
+	}
+	return Money{Minor: int64(rounded), Currency: m.Currency}
+}
+
+// Float64 returns the amount in major units. Only use it for ratios and scores, never for sums.
+func (m Money) Float64() float64 {
+	rule, _ := RuleFor(m.Currency)
+	return float64(m.Minor) / math.Pow10(rule.Exponent)
+}
+
+// String formats the amount in major units without a currency code, e.g. "-150.50"
+func (m Money) String() string {
+	rule, _ := RuleFor(m.Currency)
+	sign := ""
+	magnitude := uint64(m.Minor)
+	if m.Minor < 0 {
+		sign = "-"
+		magnitude = uint64(-m.Minor)
+	}
+
+	digits := strconv.FormatUint(magnitude, 10)
+	if rule.Exponent == 0 {
+		return sign + digits
+	}
+	if len(digits) <= rule.Exponent {
+		digits = strings.Repeat("0", rule.Exponent-len(digits)+1) + digits
+	}
+	split := len(digits) - rule.Exponent
+	return sign + digits[:split] + "." + digits[split:]
+}
+
+// mustShareCurrency returns the currency both amounts are in. Mixing currencies is a
+// programming error, amounts must be converted before they are combined.
+func (m Money) mustShareCurrency(other Money) Currency {
+	switch {
+	case m.Currency == other.Currency:
+		return m.Currency
+	case m.Currency == "" && m.Minor == 0:
+		return other.Currency
+	case other.Currency == "" && other.Minor == 0:
+		return m.Currency
+	default:
+		panic(fmt.Sprintf("money: cannot combine %s and %s amounts", m.Currency, other.Currency))
+	}
+}
+
+// roundsUp decides whether the dropped digits round the kept magnitude up by one minor unit
+func roundsUp(kept int64, dropped string, mode RoundingMode) bool {
+	if dropped == "" || dropped[0] < '5' {
+		return false
+	}
+	if dropped[0] > '5' || strings.Trim(dropped[1:], "0") != "" {
+		return true
+	}
+	// Exactly half
+	if mode == RoundHalfEven {
+		return kept%2 != 0
+	}
+	return true
+}
+
+// parseMinorDigits parses a string of digits into an int64, rejecting overflow
+func parseMinorDigits(digits string) (int64, error) {
+	if digits == "" {
+		return 0, nil
+	}
+	return strconv.ParseInt(digits, 10, 64)
+}
+
+func isDigits(s string) bool {
+	for _, r := range s {
+		if r < '0' || r > '9' {
+			return false
+		}
+	}
+	return true
+}
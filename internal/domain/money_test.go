@@ -0,0 +1,102 @@
+package domain
+
+import "testing"
+
+func TestParseMoney(t *testing.T) {
+	tests := []struct {
+		input    string
+		currency Currency
+		want     int64
+	}{
+		{"150.50", CurrencyIDR, 15050},
+		{"-150.5", CurrencyIDR, -15050},
+		{"+1000", CurrencyIDR, 100000},
+		{".75", CurrencyIDR, 75},
+		{"2500000000000.00", CurrencyIDR, 250000000000000},
+		{"0.125", CurrencyIDR, 13}, // half up
+		{"0.125", CurrencyUSD, 12}, // half even
+		{"0.135", CurrencyUSD, 14}, // half even
+		{"0.1251", CurrencyUSD, 13},
+		{"-0.125", CurrencyIDR, -13},
+		{"1500", CurrencyJPY, 1500},
+		{"1500.5", CurrencyJPY, 1501},
+	}
+
+	for _, tt := range tests {
+		got, err := ParseMoney(tt.input, tt.currency)
+		if err != nil {
+			t.Errorf("ParseMoney(%q, %s) failed: %v", tt.input, tt.currency, err)
+			continue
+		}
+		if got.Minor != tt.want || got.Currency != tt.currency {
+			t.Errorf("ParseMoney(%q, %s) = %d %s, want %d", tt.input, tt.currency, got.Minor, got.Currency, tt.want)
+		}
+	}
+}
+
+func TestParseMoney_Invalid(t *testing.T) {
+	inputs := []string{"", "-", ".", "abc", "1,000.00", "1e3", "12.3.4", "99999999999999999999"}
+	for _, input := range inputs {
+		if _, err := ParseMoney(input, CurrencyIDR); err == nil {
+			t.Errorf("ParseMoney(%q) expected error", input)
+		}
+	}
+
+	if _, err := ParseMoney("1.00", Currency("XYZ")); err == nil {
+		t.Error("Expected error for unsupported currency")
+	}
+}
+
+func TestMoney_String(t *testing.T) {
+	tests := []struct {
+		money Money
+		want  string
+	}{
+		{NewMoney(15050, CurrencyIDR), "150.50"},
+		{NewMoney(-5, CurrencyIDR), "-0.05"},
+		{NewMoney(0, CurrencyIDR), "0.00"},
+		{NewMoney(1500, CurrencyJPY), "1500"},
+		{Money{}, "0.00"},
+	}
+
+	for _, tt := range tests {
+		if got := tt.money.String(); got != tt.want {
+			t.Errorf("String() = %q, want %q", got, tt.want)
+		}
+	}
+}
+
+func TestMoney_Arithmetic(t *testing.T) {
+	a := NewMoney(15050, CurrencyIDR)
+	b := NewMoney(-5025, CurrencyIDR)
+
+	if got := a.Add(b); got.Minor != 10025 {
+		t.Errorf("Add = %d, want 10025", got.Minor)
+	}
+	if got := a.Sub(b); got.Minor != 20075 {
+		t.Errorf("Sub = %d, want 20075", got.Minor)
+	}
+	if got := b.Abs(); got.Minor != 5025 {
+		t.Errorf("Abs = %d, want 5025", got.Minor)
+	}
+
+	// The zero value adopts the other side's currency
+	var total Money
+	total = total.Add(a)
+	if total.Currency != CurrencyIDR || total.Minor != 15050 {
+		t.Errorf("Zero value Add = %d %s, want 15050 IDR", total.Minor, total.Currency)
+	}
+
+	if got := NewMoney(100000000, CurrencyIDR).Percent(0.65); got.Minor != 650000 {
+		t.Errorf("Percent = %d, want 650000", got.Minor)
+	}
+}
+
+func TestMoney_MixedCurrencyPanics(t *testing.T) {
+	defer func() {
+		if recover() == nil {
+			t.Error("Expected panic when adding IDR and USD")
+		}
+	}()
+	NewMoney(100, CurrencyIDR).Add(NewMoney(100, CurrencyUSD))
+}
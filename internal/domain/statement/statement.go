@@ -0,0 +1,156 @@
+package statement
+
+import (
+	"fmt"
+
+	"github.com/farhaan/amartha-reconcile-system/internal/domain"
+)
+
+// Statement holds the statement-level details a bank export carries around its
+// transaction table, such as the account number and balances
+type Statement struct {
+	Source         string // Bank source (e.g., "BCA", "MANDIRI")
+	FilePath       string
+	AccountNumber  string
+	Period         string
+	Currency       domain.Currency
+	OpeningBalance *domain.Money // Nil when the export does not state it
+	ClosingBalance *domain.Money // Nil when the export does not state it
+	Metadata       map[string]string
+	Check          BalanceCheck   // Result of the last VerifyBalances
+	BalanceBreaks  []BalanceBreak // Result of the last VerifyRunningBalance
+}
+
+// NewStatement creates an empty statement for a bank source
+func NewStatement(source, filePath string, currency domain.Currency) *Statement {
+	return &Statement{
+		Source:   source,
+		FilePath: filePath,
+		Currency: currency,
+		Metadata: make(map[string]string),
+	}
+}
+
+// HasBalances returns true if both opening and closing balances are known
+func (s *Statement) HasBalances() bool {
+	return s.OpeningBalance != nil && s.ClosingBalance != nil
+}
+
+// BalanceStatus is the outcome of checking a statement's balances
+type BalanceStatus string
+
+const (
+	BalanceVerified   BalanceStatus = "VERIFIED"   // Opening + transactions == closing
+	BalanceMismatch   BalanceStatus = "MISMATCH"   // Statement is incomplete or has been altered
+	BalanceUnverified BalanceStatus = "UNVERIFIED" // Opening or closing balance is unknown
+)
+
+// BalanceCheck is the result of verifying a statement against its own transactions
+type BalanceCheck struct {
+	Status     BalanceStatus
+	Total      domain.Money // Sum of the statement's transaction amounts
+	Expected   domain.Money // Closing balance
+	Computed   domain.Money // Opening balance + Total
+	Difference domain.Money // Computed - Expected
+	Reason     string
+}
+
+// VerifyBalances checks that the opening balance plus every signed transaction amount
+// in the statement equals the closing balance. Amounts must cover the whole statement,
+// not just the reconciliation period. The result is also kept in s.Check.
+func (s *Statement) VerifyBalances(amounts []domain.Money) BalanceCheck {
+	s.Check = s.checkBalances(amounts)
+	return s.Check
+}
+
+func (s *Statement) checkBalances(amounts []domain.Money) BalanceCheck {
+	check := BalanceCheck{Total: domain.NewMoney(0, s.Currency)}
+
+	if !s.HasBalances() {
+		check.Status = BalanceUnverified
+		check.Reason = "opening or closing balance not stated"
+		return check
+	}
+
+	for _, amount := range amounts {
+		if amount.Currency != s.Currency {
+			check.Status = BalanceMismatch
+			check.Reason = fmt.Sprintf("transaction in %s on a %s statement", amount.Currency, s.Currency)
+			return check
+		}
+		check.Total = check.Total.Add(amount)
+	}
+
+	check.Expected = *s.ClosingBalance
+	check.Computed = s.OpeningBalance.Add(check.Total)
+	check.Difference = check.Computed.Sub(check.Expected)
+
+	if check.Difference.IsZero() {
+		check.Status = BalanceVerified
+	} else {
+		check.Status = BalanceMismatch
+		check.Reason = fmt.Sprintf("opening %s + transactions %s = %s, closing balance is %s",
+			s.OpeningBalance, check.Total, check.Computed, check.Expected)
+	}
+	return check
+}
+
+// BalanceLine is one statement row as seen by the running balance check
+type BalanceLine struct {
+	RowNumber int64
+	ID        string
+	Amount    domain.Money  // Signed, debits negative
+	Balance   *domain.Money // Running balance after this row, nil when the row has none
+}
+
+// BalanceBreak is a row whose stated running balance does not follow from the rows before it
+type BalanceBreak struct {
+	RowNumber  int64
+	ID         string
+	Expected   domain.Money // Previous balance + this row's amount
+	Stated     domain.Money // Balance printed on the row
+	Difference domain.Money // Stated - Expected
+}
+
+// VerifyRunningBalance walks the rows in file order and checks that each stated balance
+// equals the previous balance plus the row amount. The chain starts at the opening balance
+// when it is known, otherwise at the first stated balance. After a break the chain resumes
+// from the stated balance, so one dropped or duplicated line is reported once. Rows without
+// a balance carry their amount forward. The result is also kept in s.BalanceBreaks.
+func (s *Statement) VerifyRunningBalance(lines []BalanceLine) []BalanceBreak {
+	breaks := make([]BalanceBreak, 0)
+
+	var expected *domain.Money
+	if s.OpeningBalance != nil {
+		opening := *s.OpeningBalance
+		expected = &opening
+	}
+
+	for _, line := range lines {
+		if expected != nil && expected.Currency != line.Amount.Currency {
+			expected = nil // A running balance cannot span currencies
+		}
+		if expected != nil {
+			next := expected.Add(line.Amount)
+			expected = &next
+		}
+		if line.Balance == nil {
+			continue
+		}
+
+		if expected != nil && !expected.Equal(*line.Balance) {
+			breaks = append(breaks, BalanceBreak{
+				RowNumber:  line.RowNumber,
+				ID:         line.ID,
+				Expected:   *expected,
+				Stated:     *line.Balance,
+				Difference: line.Balance.Sub(*expected),
+			})
+		}
+		stated := *line.Balance
+		expected = &stated
+	}
+
+	s.BalanceBreaks = breaks
+	return breaks
+}
@@ -0,0 +1,118 @@
+package statement
+
+import (
+	"testing"
+
+	"github.com/farhaan/amartha-reconcile-system/internal/domain"
+)
+
+func TestStatement_VerifyBalances(t *testing.T) {
+	amounts := []domain.Money{
+		domain.NewMoney(-15050, domain.CurrencyIDR),
+		domain.NewMoney(100000, domain.CurrencyIDR),
+		domain.NewMoney(-25000, domain.CurrencyIDR),
+	}
+
+	tests := []struct {
+		name    string
+		closing int64
+		want    BalanceStatus
+		diff    int64
+	}{
+		{"balanced", 1059950, BalanceVerified, 0},
+		{"missing line", 1084950, BalanceMismatch, -25000},
+	}
+
+	for _, tt := range tests {
+		stmt := newTestStatement(1000000, tt.closing)
+		check := stmt.VerifyBalances(amounts)
+
+		if check.Status != tt.want {
+			t.Errorf("%s: expected %s, got %s (%s)", tt.name, tt.want, check.Status, check.Reason)
+		}
+		if check.Difference.Minor != tt.diff {
+			t.Errorf("%s: expected difference %d, got %d", tt.name, tt.diff, check.Difference.Minor)
+		}
+		if stmt.Check.Status != check.Status {
+			t.Errorf("%s: expected result kept on the statement", tt.name)
+		}
+	}
+}
+
+func TestStatement_VerifyBalances_Unverified(t *testing.T) {
+	stmt := NewStatement("BCA", "bca_statement_2024-03-15.csv", domain.CurrencyIDR)
+
+	check := stmt.VerifyBalances([]domain.Money{domain.NewMoney(100, domain.CurrencyIDR)})
+	if check.Status != BalanceUnverified {
+		t.Errorf("Expected %s without balances, got %s", BalanceUnverified, check.Status)
+	}
+}
+
+func TestStatement_VerifyBalances_MixedCurrency(t *testing.T) {
+	stmt := newTestStatement(0, 100)
+
+	check := stmt.VerifyBalances([]domain.Money{domain.NewMoney(100, domain.CurrencyUSD)})
+	if check.Status != BalanceMismatch {
+		t.Errorf("Expected %s for a foreign currency line, got %s", BalanceMismatch, check.Status)
+	}
+}
+
+func TestStatement_VerifyRunningBalance(t *testing.T) {
+	stmt := newTestStatement(1000000, 0)
+
+	// Row 3 was dropped from the export: 10,000.00 -150.50 +1,000.00 (-250.00) +500.00
+	lines := []BalanceLine{
+		balanceLine(1, "TX001", -15050, 984950),
+		balanceLine(2, "TX002", 100000, 1084950),
+		balanceLine(4, "TX004", 50000, 1109950),
+		balanceLine(5, "TX005", -10000, 1099950),
+	}
+
+	breaks := stmt.VerifyRunningBalance(lines)
+	if len(breaks) != 1 {
+		t.Fatalf("Expected 1 break, got %d", len(breaks))
+	}
+	if breaks[0].RowNumber != 4 || breaks[0].ID != "TX004" {
+		t.Errorf("Expected break at row 4 (TX004), got row %d (%s)", breaks[0].RowNumber, breaks[0].ID)
+	}
+	if breaks[0].Difference.Minor != -25000 {
+		t.Errorf("Expected difference of the dropped -250.00, got %s", breaks[0].Difference)
+	}
+	if len(stmt.BalanceBreaks) != 1 {
+		t.Errorf("Expected breaks kept on the statement")
+	}
+}
+
+func TestStatement_VerifyRunningBalance_NoOpeningBalance(t *testing.T) {
+	stmt := NewStatement("BCA", "bca_statement_2024-03-15.csv", domain.CurrencyIDR)
+
+	// Without an opening balance the chain starts at the first row; row 2 has no balance
+	lines := []BalanceLine{
+		balanceLine(1, "TX001", -15050, 984950),
+		{RowNumber: 2, ID: "TX002", Amount: domain.NewMoney(100000, domain.CurrencyIDR)},
+		balanceLine(3, "TX003", 100000, 1184950),
+		balanceLine(4, "TX003", 100000, 1184950),
+	}
+
+	breaks := stmt.VerifyRunningBalance(lines)
+	if len(breaks) != 1 || breaks[0].RowNumber != 4 {
+		t.Fatalf("Expected the duplicated row 4 to break the chain, got %+v", breaks)
+	}
+	if breaks[0].Difference.Minor != -100000 {
+		t.Errorf("Expected difference -1000.00, got %s", breaks[0].Difference)
+	}
+}
+
+func balanceLine(row int64, id string, amount, balance int64) BalanceLine {
+	stated := domain.NewMoney(balance, domain.CurrencyIDR)
+	return BalanceLine{RowNumber: row, ID: id, Amount: domain.NewMoney(amount, domain.CurrencyIDR), Balance: &stated}
+}
+
+func newTestStatement(opening, closing int64) *Statement {
+	stmt := NewStatement("BCA", "bca_statement_2024-03-15.csv", domain.CurrencyIDR)
+	openingBalance := domain.NewMoney(opening, domain.CurrencyIDR)
+	closingBalance := domain.NewMoney(closing, domain.CurrencyIDR)
+	stmt.OpeningBalance = &openingBalance
+	stmt.ClosingBalance = &closingBalance
+	return stmt
+}
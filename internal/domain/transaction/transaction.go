@@ -13,7 +13,7 @@ type Transaction struct {
 	FileID          string
 	SourceType      domain.SourceType
 	TransactionDate time.Time
-	Amount          float64
+	Amount          domain.Money
 	Type            domain.TransactionType
 	Source          string // Bank source (e.g., "BCA", "MANDIRI")
 	RawData         map[string]any
@@ -28,7 +28,7 @@ func NewTransaction(
 	jobID, fileID string,
 	sourceType domain.SourceType,
 	transactionDate time.Time,
-	amount float64,
+	amount domain.Money,
 	txnType domain.TransactionType,
 	source string,
 ) *Transaction {
@@ -51,24 +51,21 @@ func NewTransaction(
 
 // IsDebit returns true if transaction is a debit
 func (t *Transaction) IsDebit() bool {
-	return t.Type == domain.TransactionTypeDebit || t.Amount < 0
+	return t.Type == domain.TransactionTypeDebit || t.Amount.IsNegative()
 }
 
 // AbsAmount returns the absolute value of the amount
-func (t *Transaction) AbsAmount() float64 {
-	if t.Amount < 0 {
-		return -t.Amount
-	}
-	return t.Amount
+func (t *Transaction) AbsAmount() domain.Money {
+	return t.Amount.Abs()
 }
 
 // NormalizeAmount normalizes the amount based on transaction type
 // DEBIT transactions should be negative, CREDIT should be positive
 func (t *Transaction) NormalizeAmount() {
-	if t.Type == domain.TransactionTypeDebit && t.Amount > 0 {
-		t.Amount = -t.Amount
-	} else if t.Type == domain.TransactionTypeCredit && t.Amount < 0 {
-		t.Amount = -t.Amount
+	if t.Type == domain.TransactionTypeDebit && !t.Amount.IsNegative() {
+		t.Amount = t.Amount.Neg()
+	} else if t.Type == domain.TransactionTypeCredit && t.Amount.IsNegative() {
+		t.Amount = t.Amount.Neg()
 	}
 	t.UpdatedAt = time.Now()
 }
@@ -0,0 +1,337 @@
+package camt
+
+import (
+	"encoding/xml"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/farhaan/amartha-reconcile-system/internal/domain"
+	"github.com/farhaan/amartha-reconcile-system/internal/domain/statement"
+	"github.com/farhaan/amartha-reconcile-system/internal/domain/transaction"
+)
+
+// AccountStatement is one statement (camt.053) or notification (camt.054) of a document:
+// the account details and balances, and the booked entries as bank transactions
+type AccountStatement struct {
+	Statement    *statement.Statement
+	Transactions []*transaction.Transaction
+	Skipped      int // Entries that are not booked (pending or information only)
+}
+
+// ParseFile reads a camt.053 or camt.054 XML file
+func ParseFile(filePath, source, jobID, fileID string) ([]*AccountStatement, error) {
+	file, err := os.Open(filePath)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open file %s: %w", filePath, err)
+	}
+	defer file.Close()
+
+	statements, err := Parse(file, filePath, source, jobID, fileID)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", filePath, err)
+	}
+	return statements, nil
+}
+
+// Parse converts camt.053 statements or camt.054 notifications into transactions.
+// Every booked entry (Ntry) becomes one bank transaction, signed by CdtDbtInd and dated
+// by its booking date (value date when there is none). The Source of each statement
+// is the servicing bank of the account, or the given source when it names none.
+func Parse(r io.Reader, filePath, source, jobID, fileID string) ([]*AccountStatement, error) {
+	var doc documentXML
+	if err := xml.NewDecoder(r).Decode(&doc); err != nil {
+		return nil, fmt.Errorf("failed to parse camt document: %w", err)
+	}
+
+	reports := append(doc.Statements, doc.Notifications...)
+	if len(reports) == 0 {
+		return nil, fmt.Errorf("no camt.053 Stmt or camt.054 Ntfctn found")
+	}
+
+	statements := make([]*AccountStatement, 0, len(reports))
+	for i, report := range reports {
+		stmt, err := parseReport(report, filePath, source, jobID, fileID)
+		if err != nil {
+			return nil, fmt.Errorf("statement %d (%s): %w", i+1, report.ID, err)
+		}
+		statements = append(statements, stmt)
+	}
+	return statements, nil
+}
+
+func parseReport(report reportXML, filePath, source, jobID, fileID string) (*AccountStatement, error) {
+	if servicer := report.Account.source(); servicer != "" {
+		source = servicer
+	}
+	if source == "" {
+		return nil, fmt.Errorf("account servicer is not identified")
+	}
+
+	currency := domain.DefaultCurrency
+	if report.Account.Currency != "" {
+		currency = domain.Currency(strings.ToUpper(report.Account.Currency))
+	}
+
+	stmt := statement.NewStatement(source, filePath, currency)
+	stmt.AccountNumber = report.Account.number()
+	stmt.Metadata["statementId"] = report.ID
+	if report.Period.From != "" {
+		stmt.Period = report.Period.From + " - " + report.Period.To
+	}
+
+	for _, bal := range report.Balances {
+		amount, err := bal.Amount.money(bal.CdtDbtInd)
+		if err != nil {
+			return nil, fmt.Errorf("balance %s: %w", bal.code(), err)
+		}
+		switch bal.code() {
+		case "OPBD", "PRCD":
+			if stmt.OpeningBalance == nil {
+				stmt.OpeningBalance = &amount
+			}
+		case "CLBD":
+			stmt.ClosingBalance = &amount
+		}
+	}
+
+	result := &AccountStatement{Statement: stmt, Transactions: make([]*transaction.Transaction, 0, len(report.Entries))}
+	for i, entry := range report.Entries {
+		if !entry.booked() {
+			result.Skipped++
+			continue
+		}
+
+		txn, err := parseEntry(entry, source, jobID, fileID)
+		if err != nil {
+			return nil, fmt.Errorf("entry %d: %w", i+1, err)
+		}
+		if txn.ID == "" {
+			txn.ID = fmt.Sprintf("%s-%d", report.ID, i+1)
+		}
+		result.Transactions = append(result.Transactions, txn)
+	}
+
+	return result, nil
+}
+
+// parseEntry converts one booked Ntry into a bank transaction
+func parseEntry(entry entryXML, source, jobID, fileID string) (*transaction.Transaction, error) {
+	amount, err := entry.Amount.money(entry.CdtDbtInd)
+	if err != nil {
+		return nil, err
+	}
+
+	txnType := domain.TransactionTypeCredit
+	if amount.IsNegative() {
+		txnType = domain.TransactionTypeDebit
+	}
+
+	date, err := entry.BookingDate.parse()
+	if err != nil {
+		return nil, fmt.Errorf("invalid booking date: %w", err)
+	}
+	if date.IsZero() {
+		if date, err = entry.ValueDate.parse(); err != nil {
+			return nil, fmt.Errorf("invalid value date: %w", err)
+		}
+	}
+	if date.IsZero() {
+		return nil, fmt.Errorf("entry has no booking or value date")
+	}
+
+	txn := transaction.NewTransaction(jobID, fileID, domain.SourceTypeBank, date, amount, txnType, source)
+
+	// Single-transaction entries carry the end-to-end reference; batch entries stay one line
+	var details *transactionDetailsXML
+	if len(entry.Details) == 1 {
+		details = &entry.Details[0]
+	}
+
+	endToEndID := ""
+	if details != nil && details.Refs.EndToEndID != "NOTPROVIDED" {
+		endToEndID = details.Refs.EndToEndID
+	}
+
+	txn.ID = firstNonEmpty(entry.AcctSvcrRef, detailsRef(details), endToEndID, entry.NtryRef)
+	txn.RawData = map[string]any{
+		"acctSvcrRef": entry.AcctSvcrRef,
+		"endToEndId":  endToEndID,
+		"ntryRef":     entry.NtryRef,
+		"amount":      entry.Amount.Value,
+		"currency":    entry.Amount.Currency,
+		"cdtDbtInd":   entry.CdtDbtInd,
+		"bookingDate": entry.BookingDate.value(),
+		"valueDate":   entry.ValueDate.value(),
+		"reversal":    entry.Reversal,
+		"bankSource":  source,
+		"batchSize":   len(entry.Details),
+	}
+	if details != nil {
+		txn.RawData["remittanceInfo"] = strings.Join(details.Remittance, " ")
+	}
+
+	txn.NormalizeAmount()
+	return txn, nil
+}
+
+func detailsRef(details *transactionDetailsXML) string {
+	if details == nil {
+		return ""
+	}
+	return details.Refs.AcctSvcrRef
+}
+
+func firstNonEmpty(values ...string) string {
+	for _, v := range values {
+		if v = strings.TrimSpace(v); v != "" {
+			return v
+		}
+	}
+	return ""
+}
+
+type documentXML struct {
+	Statements    []reportXML `xml:"BkToCstmrStmt>Stmt"`
+	Notifications []reportXML `xml:"BkToCstmrDbtCdtNtfctn>Ntfctn"`
+}
+
+type reportXML struct {
+	ID     string `xml:"Id"`
+	Period struct {
+		From string `xml:"FrDtTm"`
+		To   string `xml:"ToDtTm"`
+	} `xml:"FrToDt"`
+	Account  accountXML   `xml:"Acct"`
+	Balances []balanceXML `xml:"Bal"`
+	Entries  []entryXML   `xml:"Ntry"`
+}
+
+type accountXML struct {
+	IBAN     string `xml:"Id>IBAN"`
+	Other    string `xml:"Id>Othr>Id"`
+	Currency string `xml:"Ccy"`
+	Servicer struct {
+		BIC   string `xml:"FinInstnId>BIC"`
+		BICFI string `xml:"FinInstnId>BICFI"` // camt.053.001.04 and later
+		Name  string `xml:"FinInstnId>Nm"`
+	} `xml:"Svcr"`
+}
+
+func (a accountXML) number() string {
+	return firstNonEmpty(a.IBAN, a.Other)
+}
+
+// source names the servicing bank: its BIC's bank code when it is a known bank,
+// otherwise the servicer name, otherwise the bank code itself
+func (a accountXML) source() string {
+	bic := strings.ToUpper(firstNonEmpty(a.Servicer.BICFI, a.Servicer.BIC))
+	if name, ok := domain.BankFromBIC(bic); ok {
+		return name
+	}
+
+	code := ""
+	if len(bic) >= 4 {
+		code = bic[:4]
+	}
+	return strings.ToUpper(firstNonEmpty(a.Servicer.Name, code))
+}
+
+type amountXML struct {
+	Value    string `xml:",chardata"`
+	Currency string `xml:"Ccy,attr"`
+}
+
+// money parses an unsigned camt amount, negating it for debits
+func (a amountXML) money(cdtDbtInd string) (domain.Money, error) {
+	currency := domain.DefaultCurrency
+	if a.Currency != "" {
+		currency = domain.Currency(strings.ToUpper(a.Currency))
+	}
+
+	amount, err := domain.ParseMoney(strings.TrimSpace(a.Value), currency)
+	if err != nil {
+		return domain.Money{}, err
+	}
+
+	switch strings.TrimSpace(cdtDbtInd) {
+	case "DBIT":
+		return amount.Neg(), nil
+	case "CRDT":
+		return amount, nil
+	default:
+		return domain.Money{}, fmt.Errorf("invalid CdtDbtInd %q", cdtDbtInd)
+	}
+}
+
+type balanceXML struct {
+	Code        string    `xml:"Tp>CdOrPrtry>Cd"`
+	Proprietary string    `xml:"Tp>CdOrPrtry>Prtry"`
+	Amount      amountXML `xml:"Amt"`
+	CdtDbtInd   string    `xml:"CdtDbtInd"`
+}
+
+func (b balanceXML) code() string {
+	return strings.ToUpper(firstNonEmpty(b.Code, b.Proprietary))
+}
+
+type entryXML struct {
+	NtryRef   string    `xml:"NtryRef"`
+	Amount    amountXML `xml:"Amt"`
+	CdtDbtInd string    `xml:"CdtDbtInd"`
+	Reversal  bool      `xml:"RvslInd"`
+	Status    struct {
+		Value string `xml:",chardata"` // camt.053.001.02
+		Code  string `xml:"Cd"`        // camt.053.001.08 and later
+	} `xml:"Sts"`
+	BookingDate dateXML                 `xml:"BookgDt"`
+	ValueDate   dateXML                 `xml:"ValDt"`
+	AcctSvcrRef string                  `xml:"AcctSvcrRef"`
+	Details     []transactionDetailsXML `xml:"NtryDtls>TxDtls"`
+}
+
+// booked reports whether the entry is booked. Entries without a status are treated as booked.
+func (e entryXML) booked() bool {
+	status := strings.ToUpper(firstNonEmpty(e.Status.Code, e.Status.Value))
+	return status == "" || status == "BOOK"
+}
+
+type transactionDetailsXML struct {
+	Refs struct {
+		EndToEndID  string `xml:"EndToEndId"`
+		AcctSvcrRef string `xml:"AcctSvcrRef"`
+	} `xml:"Refs"`
+	Remittance []string `xml:"RmtInf>Ustrd"`
+}
+
+type dateXML struct {
+	Date     string `xml:"Dt"`
+	DateTime string `xml:"DtTm"`
+}
+
+func (d dateXML) value() string {
+	return firstNonEmpty(d.Date, d.DateTime)
+}
+
+// parse returns the date, or the zero time when the element is absent
+func (d dateXML) parse() (time.Time, error) {
+	if date := strings.TrimSpace(d.Date); date != "" {
+		return time.Parse("2006-01-02", date)
+	}
+	if dateTime := strings.TrimSpace(d.DateTime); dateTime != "" {
+		return parseDateTime(dateTime)
+	}
+	return time.Time{}, nil
+}
+
+// parseDateTime parses ISO date-times with or without a zone, e.g. 2024-03-15T10:30:00+07:00
+func parseDateTime(s string) (time.Time, error) {
+	for _, layout := range []string{time.RFC3339Nano, "2006-01-02T15:04:05.999999999"} {
+		if t, err := time.Parse(layout, s); err == nil {
+			return t, nil
+		}
+	}
+	return time.Time{}, fmt.Errorf("unable to parse date time: %s", s)
+}
@@ -0,0 +1,197 @@
+package camt
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/farhaan/amartha-reconcile-system/internal/domain"
+	"github.com/farhaan/amartha-reconcile-system/internal/domain/statement"
+)
+
+const testCamt053 = `<?xml version="1.0" encoding="UTF-8"?>
+<Document xmlns="urn:iso:std:iso:20022:tech:xsd:camt.053.001.08">
+  <BkToCstmrStmt>
+    <Stmt>
+      <Id>STMT-1</Id>
+      <FrToDt><FrDtTm>2024-03-15T00:00:00+07:00</FrDtTm><ToDtTm>2024-03-22T23:59:59+07:00</ToDtTm></FrToDt>
+      <Acct>
+        <Id><IBAN>ID0001234567890</IBAN></Id>
+        <Ccy>IDR</Ccy>
+        <Svcr><FinInstnId><BICFI>BMRIIDJA</BICFI></FinInstnId></Svcr>
+      </Acct>
+      <Bal><Tp><CdOrPrtry><Cd>OPBD</Cd></CdOrPrtry></Tp><Amt Ccy="IDR">100.00</Amt><CdtDbtInd>DBIT</CdtDbtInd></Bal>
+      <Bal><Tp><CdOrPrtry><Cd>CLBD</Cd></CdOrPrtry></Tp><Amt Ccy="IDR">750.00</Amt><CdtDbtInd>CRDT</CdtDbtInd></Bal>
+      <Ntry>
+        <Amt Ccy="IDR">1000.00</Amt>
+        <CdtDbtInd>CRDT</CdtDbtInd>
+        <Sts><Cd>BOOK</Cd></Sts>
+        <BookgDt><DtTm>2024-03-15T10:30:00+07:00</DtTm></BookgDt>
+        <NtryDtls><TxDtls><Refs><EndToEndId>E2E-1</EndToEndId></Refs><RmtInf><Ustrd>LOAN</Ustrd></RmtInf></TxDtls></NtryDtls>
+      </Ntry>
+      <Ntry>
+        <NtryRef>42</NtryRef>
+        <Amt Ccy="IDR">150.00</Amt>
+        <CdtDbtInd>DBIT</CdtDbtInd>
+        <Sts><Cd>BOOK</Cd></Sts>
+        <ValDt><Dt>2024-03-16</Dt></ValDt>
+        <NtryDtls><TxDtls><Refs><EndToEndId>NOTPROVIDED</EndToEndId></Refs></TxDtls></NtryDtls>
+      </Ntry>
+      <Ntry>
+        <Amt Ccy="IDR">99.00</Amt>
+        <CdtDbtInd>CRDT</CdtDbtInd>
+        <Sts><Cd>PDNG</Cd></Sts>
+        <BookgDt><Dt>2024-03-17</Dt></BookgDt>
+      </Ntry>
+      <Ntry>
+        <Amt Ccy="IDR">1.00</Amt>
+        <CdtDbtInd>CRDT</CdtDbtInd>
+        <BookgDt><Dt>2024-03-18</Dt></BookgDt>
+        <NtryDtls><TxDtls/><TxDtls/></NtryDtls>
+      </Ntry>
+    </Stmt>
+  </BkToCstmrStmt>
+</Document>`
+
+const testCamt054 = `<Document xmlns="urn:iso:std:iso:20022:tech:xsd:camt.054.001.02">
+  <BkToCstmrDbtCdtNtfctn>
+    <Ntfctn>
+      <Id>NTF-1</Id>
+      <Acct>
+        <Id><Othr><Id>0123456789</Id></Othr></Id>
+        <Svcr><FinInstnId><BIC>XYZBIDJA</BIC><Nm>Bank Xyz</Nm></FinInstnId></Svcr>
+      </Acct>
+      <Ntry>
+        <Amt Ccy="USD">12.34</Amt>
+        <CdtDbtInd>DBIT</CdtDbtInd>
+        <Sts>BOOK</Sts>
+        <BookgDt><Dt>2024-03-15</Dt></BookgDt>
+        <AcctSvcrRef>REF-9</AcctSvcrRef>
+      </Ntry>
+    </Ntfctn>
+  </BkToCstmrDbtCdtNtfctn>
+</Document>`
+
+func TestParse_Camt053(t *testing.T) {
+	statements, err := Parse(strings.NewReader(testCamt053), "stmt.xml", "", "job", "file")
+	if err != nil {
+		t.Fatalf("Parse failed: %v", err)
+	}
+	if len(statements) != 1 {
+		t.Fatalf("Expected 1 statement, got %d", len(statements))
+	}
+
+	result := statements[0]
+	stmt := result.Statement
+	if stmt.Source != "MANDIRI" {
+		t.Errorf("Expected source MANDIRI from BICFI, got %s", stmt.Source)
+	}
+	if stmt.AccountNumber != "ID0001234567890" {
+		t.Errorf("Expected IBAN account number, got %s", stmt.AccountNumber)
+	}
+	if stmt.OpeningBalance == nil || stmt.OpeningBalance.Minor != -10000 {
+		t.Errorf("Expected opening balance -100.00, got %v", stmt.OpeningBalance)
+	}
+	if stmt.ClosingBalance == nil || stmt.ClosingBalance.Minor != 75000 {
+		t.Errorf("Expected closing balance 750.00, got %v", stmt.ClosingBalance)
+	}
+	if result.Skipped != 1 {
+		t.Errorf("Expected 1 pending entry skipped, got %d", result.Skipped)
+	}
+
+	tests := []struct {
+		id      string
+		minor   int64
+		txnType domain.TransactionType
+		date    string
+	}{
+		{"E2E-1", 100000, domain.TransactionTypeCredit, "2024-03-15"},
+		{"42", -15000, domain.TransactionTypeDebit, "2024-03-16"},
+		{"STMT-1-4", 100, domain.TransactionTypeCredit, "2024-03-18"},
+	}
+
+	if len(result.Transactions) != len(tests) {
+		t.Fatalf("Expected %d transactions, got %d", len(tests), len(result.Transactions))
+	}
+	for i, tt := range tests {
+		txn := result.Transactions[i]
+		if txn.ID != tt.id {
+			t.Errorf("entry %d: expected ID %s, got %s", i+1, tt.id, txn.ID)
+		}
+		if txn.Amount.Minor != tt.minor {
+			t.Errorf("entry %d: expected amount %d, got %d", i+1, tt.minor, txn.Amount.Minor)
+		}
+		if txn.Type != tt.txnType {
+			t.Errorf("entry %d: expected type %s, got %s", i+1, tt.txnType, txn.Type)
+		}
+		if got := txn.TransactionDate.Format(time.DateOnly); got != tt.date {
+			t.Errorf("entry %d: expected date %s, got %s", i+1, tt.date, got)
+		}
+		if txn.Source != "MANDIRI" {
+			t.Errorf("entry %d: expected source MANDIRI, got %s", i+1, txn.Source)
+		}
+	}
+
+	if got := stmt.VerifyBalances([]domain.Money{
+		result.Transactions[0].Amount, result.Transactions[1].Amount, result.Transactions[2].Amount,
+	}); got.Status != statement.BalanceMismatch {
+		// -100 + 1000 - 150 + 1 = 751, the closing balance says 750
+		t.Errorf("Expected MISMATCH, got %s", got.Status)
+	}
+}
+
+func TestParse_Camt054(t *testing.T) {
+	statements, err := Parse(strings.NewReader(testCamt054), "ntf.xml", "", "job", "file")
+	if err != nil {
+		t.Fatalf("Parse failed: %v", err)
+	}
+	if len(statements) != 1 || len(statements[0].Transactions) != 1 {
+		t.Fatalf("Expected 1 notification with 1 entry, got %+v", statements)
+	}
+
+	stmt := statements[0].Statement
+	if stmt.Source != "BANK XYZ" {
+		t.Errorf("Expected source from the servicer name, got %s", stmt.Source)
+	}
+	if stmt.HasBalances() {
+		t.Error("Expected a notification without balances")
+	}
+
+	txn := statements[0].Transactions[0]
+	if txn.ID != "REF-9" {
+		t.Errorf("Expected ID REF-9, got %s", txn.ID)
+	}
+	if txn.Amount.Currency != "USD" || txn.Amount.Minor != -1234 {
+		t.Errorf("Expected -12.34 USD, got %d %s", txn.Amount.Minor, txn.Amount.Currency)
+	}
+}
+
+func TestParse_GivenSource(t *testing.T) {
+	doc := `<Document><BkToCstmrStmt><Stmt><Id>1</Id></Stmt></BkToCstmrStmt></Document>`
+
+	statements, err := Parse(strings.NewReader(doc), "x.xml", "BNI", "job", "file")
+	if err != nil {
+		t.Fatalf("Parse failed: %v", err)
+	}
+	if statements[0].Statement.Source != "BNI" {
+		t.Errorf("Expected the given source without a servicer, got %s", statements[0].Statement.Source)
+	}
+}
+
+func TestParse_Invalid(t *testing.T) {
+	tests := map[string]string{
+		"not camt":       `<Document><Other/></Document>`,
+		"bad xml":        `<Document><BkToCstmrStmt>`,
+		"no servicer":    `<Document><BkToCstmrStmt><Stmt><Id>1</Id></Stmt></BkToCstmrStmt></Document>`,
+		"bad indicator":  `<Document><BkToCstmrStmt><Stmt><Acct><Svcr><FinInstnId><BIC>CENAIDJA</BIC></FinInstnId></Svcr></Acct><Ntry><Amt>1.00</Amt><CdtDbtInd>X</CdtDbtInd><BookgDt><Dt>2024-03-15</Dt></BookgDt></Ntry></Stmt></BkToCstmrStmt></Document>`,
+		"bad date":       `<Document><BkToCstmrStmt><Stmt><Acct><Svcr><FinInstnId><BIC>CENAIDJA</BIC></FinInstnId></Svcr></Acct><Ntry><Amt>1.00</Amt><CdtDbtInd>CRDT</CdtDbtInd><BookgDt><Dt>15/03/2024</Dt></BookgDt></Ntry></Stmt></BkToCstmrStmt></Document>`,
+		"missing date":   `<Document><BkToCstmrStmt><Stmt><Acct><Svcr><FinInstnId><BIC>CENAIDJA</BIC></FinInstnId></Svcr></Acct><Ntry><Amt>1.00</Amt><CdtDbtInd>CRDT</CdtDbtInd></Ntry></Stmt></BkToCstmrStmt></Document>`,
+		"invalid amount": `<Document><BkToCstmrStmt><Stmt><Acct><Svcr><FinInstnId><BIC>CENAIDJA</BIC></FinInstnId></Svcr></Acct><Ntry><Amt>1,000.00</Amt><CdtDbtInd>CRDT</CdtDbtInd><BookgDt><Dt>2024-03-15</Dt></BookgDt></Ntry></Stmt></BkToCstmrStmt></Document>`,
+	}
+
+	for name, doc := range tests {
+		if _, err := Parse(strings.NewReader(doc), "x.xml", "", "job", "file"); err == nil {
+			t.Errorf("%s: expected error", name)
+		}
+	}
+}
@@ -0,0 +1,37 @@
+package csv
+
+import "fmt"
+
+// ErrorCategory classifies why a row was rejected
+type ErrorCategory string
+
+const (
+	CategoryUnreadable  ErrorCategory = "unreadable"         // Malformed CSV, e.g. a stray quote
+	CategoryColumnCount ErrorCategory = "wrong_column_count" // Row is not as wide as the header
+	CategoryBadAmount   ErrorCategory = "bad_amount"
+	CategoryBadCurrency ErrorCategory = "bad_currency"
+	CategoryBadType     ErrorCategory = "bad_type"
+	CategoryBadDate     ErrorCategory = "bad_date"
+	CategoryNoSource    ErrorCategory = "no_source" // Neither the row nor the file names a bank
+	CategoryOther       ErrorCategory = "other"
+)
+
+// RowError is a row that could not be read or parsed, with the raw record when there is one
+type RowError struct {
+	Row      int64
+	Record   []string
+	Category ErrorCategory
+	Err      error
+}
+
+func (e *RowError) Error() string {
+	return fmt.Sprintf("row %d: %v", e.Row, e.Err)
+}
+
+func (e *RowError) Unwrap() error {
+	return e.Err
+}
+
+func rowError(row int64, record []string, category ErrorCategory, err error) *RowError {
+	return &RowError{Row: row, Record: record, Category: category, Err: err}
+}
@@ -0,0 +1,57 @@
+package csv
+
+import (
+	"errors"
+	"fmt"
+)
+
+// ErrValidation is wrapped by every validation policy violation
+var ErrValidation = errors.New("file failed validation")
+
+// ValidationPolicy decides whether a file with rejected rows can still be reconciled
+type ValidationPolicy struct {
+	FailOnReject    bool    // Any rejected row fails the file
+	MaxRejectPct    float64 // Fail when more than this percentage of rows is rejected, 100 for no limit
+	FailOnEmpty     bool    // Fail when the file has no valid rows
+	FailOnFileError bool    // Fail when the file cannot be read at all, instead of skipping it
+}
+
+// DefaultValidationPolicy accepts every file that can be read, however many rows are rejected
+func DefaultValidationPolicy() ValidationPolicy {
+	return ValidationPolicy{MaxRejectPct: 100}
+}
+
+// StrictValidationPolicy fails on any rejected row, empty file or unreadable file
+func StrictValidationPolicy() ValidationPolicy {
+	return ValidationPolicy{
+		FailOnReject:    true,
+		MaxRejectPct:    100,
+		FailOnEmpty:     true,
+		FailOnFileError: true,
+	}
+}
+
+// Validate checks that the reject percentage is between 0 and 100
+func (p ValidationPolicy) Validate() error {
+	if p.MaxRejectPct < 0 || p.MaxRejectPct > 100 {
+		return fmt.Errorf("max reject percentage must be between 0 and 100, got %v", p.MaxRejectPct)
+	}
+	return nil
+}
+
+// Check returns an error wrapping ErrValidation when a file with the given numbers
+// of valid and rejected rows breaks the policy
+func (p ValidationPolicy) Check(valid, rejected int) error {
+	total := valid + rejected
+
+	switch {
+	case p.FailOnEmpty && valid == 0:
+		return fmt.Errorf("%w: no valid rows (%d rejected)", ErrValidation, rejected)
+	case p.FailOnReject && rejected > 0:
+		return fmt.Errorf("%w: %d of %d rows rejected", ErrValidation, rejected, total)
+	case total > 0 && float64(rejected)*100 > p.MaxRejectPct*float64(total):
+		return fmt.Errorf("%w: %d of %d rows rejected (%.1f%%), above the %.1f%% limit",
+			ErrValidation, rejected, total, float64(rejected)*100/float64(total), p.MaxRejectPct)
+	}
+	return nil
+}
@@ -0,0 +1,47 @@
+package csv
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestValidationPolicy_Check(t *testing.T) {
+	tests := []struct {
+		name     string
+		policy   ValidationPolicy
+		valid    int
+		rejected int
+		wantErr  bool
+	}{
+		{"default accepts mostly rejected file", DefaultValidationPolicy(), 1, 9, false},
+		{"default accepts empty file", DefaultValidationPolicy(), 0, 0, false},
+		{"strict fails on one reject", StrictValidationPolicy(), 99, 1, true},
+		{"strict fails on empty file", StrictValidationPolicy(), 0, 0, true},
+		{"strict accepts clean file", StrictValidationPolicy(), 10, 0, false},
+		{"at the limit", ValidationPolicy{MaxRejectPct: 10}, 9, 1, false},
+		{"above the limit", ValidationPolicy{MaxRejectPct: 10}, 8, 2, true},
+		{"zero limit fails on any reject", ValidationPolicy{MaxRejectPct: 0}, 10, 1, true},
+		{"empty only", ValidationPolicy{MaxRejectPct: 100, FailOnEmpty: true}, 0, 3, true},
+	}
+
+	for _, tt := range tests {
+		err := tt.policy.Check(tt.valid, tt.rejected)
+		if (err != nil) != tt.wantErr {
+			t.Errorf("%s: expected error %v, got %v", tt.name, tt.wantErr, err)
+		}
+		if err != nil && !errors.Is(err, ErrValidation) {
+			t.Errorf("%s: expected ErrValidation, got %v", tt.name, err)
+		}
+	}
+}
+
+func TestValidationPolicy_Validate(t *testing.T) {
+	for _, pct := range []float64{-1, 100.5} {
+		if err := (ValidationPolicy{MaxRejectPct: pct}).Validate(); err == nil {
+			t.Errorf("Expected error for %v%%", pct)
+		}
+	}
+	if err := DefaultValidationPolicy().Validate(); err != nil {
+		t.Errorf("Expected the default policy to be valid, got %v", err)
+	}
+}
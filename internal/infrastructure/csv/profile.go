@@ -0,0 +1,266 @@
+package csv
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"strings"
+)
+
+// Sign conventions for a single amount column
+const (
+	SignNegativeDebit = "negative_debit" // Debits are negative (default)
+	SignPositiveDebit = "positive_debit" // Debits are positive, credits negative
+)
+
+// ColumnProfile describes which columns of a bank export mean what.
+// Column names are matched against the header row case-insensitively.
+// Either Amount or Debit/Credit must be set.
+type ColumnProfile struct {
+	ID          string `json:"id"`
+	Date        string `json:"date"`
+	DateFormat  string `json:"date_format,omitempty"` // Go layout, e.g. "02/01/2006". Empty tries the common formats
+	Amount      string `json:"amount,omitempty"`      // Signed amount column
+	Sign        string `json:"sign,omitempty"`        // negative_debit (default) or positive_debit
+	Debit       string `json:"debit,omitempty"`       // Unsigned debit column
+	Credit      string `json:"credit,omitempty"`      // Unsigned credit column
+	Currency    string `json:"currency,omitempty"`
+	Description string `json:"description,omitempty"`
+	Balance     string `json:"balance,omitempty"`
+	ValueDate   string `json:"value_date,omitempty"`
+	Source      string `json:"source,omitempty"`      // Bank of each row, for exports that combine several banks
+	HeaderRow   int    `json:"header_row,omitempty"`  // 1-based line of the header row, 0 to detect it
+	FooterRows  int    `json:"footer_rows,omitempty"` // Number of summary rows after the table, 0 to detect them
+	Sheet       string `json:"sheet,omitempty"`       // Worksheet to read from XLSX exports, empty for the first
+
+	// Separators of amount and balance cells, e.g. "," and "." for "1.500.000,00".
+	// The decimal separator defaults to "."; without a thousands separator amounts are not grouped.
+	DecimalSeparator   string `json:"decimal_separator,omitempty"`
+	ThousandsSeparator string `json:"thousands_separator,omitempty"`
+}
+
+// DefaultBankProfile returns the profile of the standard unique_identifier,amount,date layout
+func DefaultBankProfile() *ColumnProfile {
+	return &ColumnProfile{
+		ID:       "unique_identifier",
+		Date:     "date",
+		Amount:   "amount",
+		Currency: "currency",
+	}
+}
+
+// Validate checks that the profile names an ID, a date and exactly one way to read amounts
+func (p *ColumnProfile) Validate() error {
+	if p.ID == "" || p.Date == "" {
+		return fmt.Errorf("profile must name the id and date columns")
+	}
+
+	split := p.Debit != "" || p.Credit != ""
+	switch {
+	case p.Amount == "" && !split:
+		return fmt.Errorf("profile must name an amount column or debit/credit columns")
+	case p.Amount != "" && split:
+		return fmt.Errorf("profile cannot name both an amount column and debit/credit columns")
+	case split && (p.Debit == "" || p.Credit == ""):
+		return fmt.Errorf("profile must name both debit and credit columns")
+	}
+
+	if p.HeaderRow < 0 || p.FooterRows < 0 {
+		return fmt.Errorf("header_row and footer_rows cannot be negative")
+	}
+
+	switch p.Sign {
+	case "", SignNegativeDebit, SignPositiveDebit:
+	default:
+		return fmt.Errorf("unknown sign convention %q", p.Sign)
+	}
+
+	switch p.DecimalSeparator {
+	case "", ".", ",":
+	default:
+		return fmt.Errorf("decimal_separator must be \".\" or \",\", got %q", p.DecimalSeparator)
+	}
+	switch p.ThousandsSeparator {
+	case "", ".", ",", " ", "'":
+	default:
+		return fmt.Errorf("unknown thousands_separator %q", p.ThousandsSeparator)
+	}
+	if p.ThousandsSeparator == p.decimalSeparator() {
+		return fmt.Errorf("thousands_separator and decimal_separator cannot both be %q", p.ThousandsSeparator)
+	}
+
+	return nil
+}
+
+func (p *ColumnProfile) decimalSeparator() string {
+	if p.DecimalSeparator == "" {
+		return "."
+	}
+	return p.DecimalSeparator
+}
+
+// Number converts an amount written with the profile's separators into the plain
+// decimal ParseMoney reads, e.g. "1.500.000,00" into "1500000.00"
+func (p *ColumnProfile) Number(s string) string {
+	s = strings.TrimSpace(s)
+	if p.ThousandsSeparator != "" {
+		s = strings.ReplaceAll(s, p.ThousandsSeparator, "")
+	}
+	if sep := p.decimalSeparator(); sep != "." {
+		s = strings.Replace(s, sep, ".", 1)
+	}
+	return s
+}
+
+// NormalizeBalances rewrites the opening and closing balances of preamble and footer
+// metadata with Number, so ParseStatement can read them
+func (p *ColumnProfile) NormalizeBalances(metadata map[string]string) {
+	for _, labels := range [][]string{openingBalanceLabels, closingBalanceLabels} {
+		for _, label := range labels {
+			if value, ok := metadata[label]; ok {
+				metadata[label] = p.Number(value)
+			}
+		}
+	}
+}
+
+// LoadProfiles reads bank column profiles from a JSON file keyed by bank source, e.g.
+// {"BNI": {"id": "Ref No", "date": "Tanggal", "debit": "Debet", "credit": "Kredit"}}.
+// Bank names are upper-cased to match transaction sources.
+func LoadProfiles(filePath string) (map[string]*ColumnProfile, error) {
+	data, err := os.ReadFile(filePath)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open file %s: %w", filePath, err)
+	}
+
+	raw := make(map[string]*ColumnProfile)
+	if err := json.Unmarshal(data, &raw); err != nil {
+		return nil, fmt.Errorf("failed to parse bank profiles %s: %w", filePath, err)
+	}
+
+	profiles := make(map[string]*ColumnProfile, len(raw))
+	for bank, profile := range raw {
+		if profile == nil {
+			return nil, fmt.Errorf("bank profile %s is empty", bank)
+		}
+		if err := profile.Validate(); err != nil {
+			return nil, fmt.Errorf("bank profile %s: %w", bank, err)
+		}
+		profiles[strings.ToUpper(strings.TrimSpace(bank))] = profile
+	}
+
+	return profiles, nil
+}
+
+// columnIndex maps the columns named by a profile to their positions in the header row
+type columnIndex struct {
+	id, date, amount, debit, credit                   int
+	currency, description, balance, valueDate, source int
+}
+
+// resolveColumns finds each profile column in the headers. Required columns must be
+// present; optional ones (currency, description, balance, value date, source) resolve to -1.
+func (p *ColumnProfile) resolveColumns(headers []string) (*columnIndex, error) {
+	find := func(name string, required bool) (int, error) {
+		if name == "" {
+			return -1, nil
+		}
+		for i, header := range headers {
+			if strings.EqualFold(strings.TrimSpace(header), name) {
+				return i, nil
+			}
+		}
+		if required {
+			return -1, fmt.Errorf("column %q not found in headers %v", name, headers)
+		}
+		return -1, nil
+	}
+
+	idx := &columnIndex{}
+	var err error
+	required := []struct {
+		name string
+		dst  *int
+	}{
+		{p.ID, &idx.id},
+		{p.Date, &idx.date},
+		{p.Amount, &idx.amount},
+		{p.Debit, &idx.debit},
+		{p.Credit, &idx.credit},
+	}
+	for _, col := range required {
+		if *col.dst, err = find(col.name, true); err != nil {
+			return nil, err
+		}
+	}
+
+	optional := []struct {
+		name string
+		dst  *int
+	}{
+		{p.Currency, &idx.currency},
+		{p.Description, &idx.description},
+		{p.Balance, &idx.balance},
+		{p.ValueDate, &idx.valueDate},
+		{p.Source, &idx.source},
+	}
+	for _, col := range optional {
+		*col.dst, _ = find(col.name, false)
+	}
+
+	return idx, nil
+}
+
+// signedAmount returns the row amount as a signed decimal string where debits are negative
+func (p *ColumnProfile) signedAmount(record []string, idx *columnIndex) (string, error) {
+	if idx.amount >= 0 {
+		amount := p.Number(record[idx.amount])
+		if p.Sign == SignPositiveDebit {
+			return negate(amount), nil
+		}
+		return amount, nil
+	}
+
+	debit := p.Number(record[idx.debit])
+	credit := p.Number(record[idx.credit])
+	hasDebit, hasCredit := !isBlankAmount(debit), !isBlankAmount(credit)
+
+	switch {
+	case hasDebit && hasCredit:
+		return "", fmt.Errorf("both debit %q and credit %q are set", debit, credit)
+	case hasDebit:
+		return "-" + unsigned(debit), nil
+	case hasCredit:
+		return unsigned(credit), nil
+	default:
+		return "", fmt.Errorf("neither debit nor credit is set")
+	}
+}
+
+// field returns the trimmed value of an optional column, or empty when it is not mapped
+func field(record []string, i int) string {
+	if i < 0 || i >= len(record) {
+		return ""
+	}
+	return strings.TrimSpace(record[i])
+}
+
+// isBlankAmount reports whether a debit/credit cell is empty or zero
+func isBlankAmount(s string) bool {
+	return strings.Trim(s, "0.+-") == ""
+}
+
+func unsigned(s string) string {
+	return strings.TrimLeft(s, "+-")
+}
+
+func negate(s string) string {
+	switch {
+	case strings.HasPrefix(s, "-"):
+		return s[1:]
+	case strings.HasPrefix(s, "+"):
+		return "-" + s[1:]
+	default:
+		return "-" + s
+	}
+}
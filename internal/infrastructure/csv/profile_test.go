@@ -0,0 +1,163 @@
+package csv
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestReadBankStatementsWithProfile_SplitColumns(t *testing.T) {
+	path := writeTempCSV(t, "bni_statement_2024-03-15.csv",
+		"Tanggal,Keterangan,Ref No,Debet,Kredit,Saldo\n"+
+			"17/03/2024,TRANSFER KE AMARTHA,BNI_001,500.75,,9499.25\n"+
+			"18/03/2024,TRANSFER DARI MITRA,BNI_002,0.00,1500.50,10999.75\n"+
+			"19/03/2024,KOREKSI,BNI_003,10.00,10.00,10999.75\n")
+
+	profile := &ColumnProfile{
+		ID:          "ref no",
+		Date:        "Tanggal",
+		DateFormat:  "02/01/2006",
+		Debit:       "Debet",
+		Credit:      "Kredit",
+		Description: "Keterangan",
+		Balance:     "Saldo",
+	}
+
+	rows, errs := readWithProfile(t, path, profile)
+
+	if len(rows) != 2 || len(errs) != 1 {
+		t.Fatalf("Expected 2 rows and 1 error, got %d rows and %d errors", len(rows), len(errs))
+	}
+
+	if rows[0].UniqueIdentifier != "BNI_001" || rows[0].Amount != "-500.75" {
+		t.Errorf("Expected BNI_001 debit -500.75, got %s %s", rows[0].UniqueIdentifier, rows[0].Amount)
+	}
+	if rows[1].Amount != "1500.50" || rows[1].Balance != "10999.75" || rows[1].Description != "TRANSFER DARI MITRA" {
+		t.Errorf("Unexpected credit row: %+v", rows[1])
+	}
+
+	txn, err := ParseBankTransaction(rows[0], "job", "file", "BNI")
+	if err != nil {
+		t.Fatalf("ParseBankTransaction failed: %v", err)
+	}
+	if !txn.IsDebit() || txn.TransactionDate.Day() != 17 || txn.TransactionDate.Month() != 3 {
+		t.Errorf("Expected debit on 2024-03-17, got type %v on %s", txn.Type, txn.TransactionDate)
+	}
+}
+
+func TestReadBankStatementsWithProfile_PositiveDebit(t *testing.T) {
+	path := writeTempCSV(t, "bca_statement_2024-03-15.csv",
+		"Amount,Trx ID,Posting Date\n"+
+			"150.50,BCA_001,2024-03-15\n"+
+			"-1000.00,BCA_002,2024-03-16\n")
+
+	profile := &ColumnProfile{ID: "Trx ID", Date: "Posting Date", Amount: "Amount", Sign: SignPositiveDebit}
+
+	rows, errs := readWithProfile(t, path, profile)
+	if len(errs) != 0 {
+		t.Fatalf("Unexpected errors: %v", errs)
+	}
+
+	if rows[0].Amount != "-150.50" || rows[1].Amount != "1000.00" {
+		t.Errorf("Expected amounts flipped to -150.50 and 1000.00, got %s and %s", rows[0].Amount, rows[1].Amount)
+	}
+}
+
+func TestReadBankStatementsWithProfile_Separators(t *testing.T) {
+	tests := []struct {
+		name     string
+		content  string
+		decimal  string
+		thousand string
+	}{
+		{"grouped with commas", "Ref,Tanggal,Mutasi,Saldo\nBCA_001,15/03/2024,\"-1,500,000.00\",\"8,500,000.00\"\n", "", ","},
+		{"grouped with dots", "Ref,Tanggal,Mutasi,Saldo\nBCA_001,15/03/2024,\"-1.500.000,00\",\"8.500.000,00\"\n", ",", "."},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			path := writeTempCSV(t, "bca_statement_2024-03-15.csv", tt.content)
+			profile := &ColumnProfile{ID: "Ref", Date: "Tanggal", DateFormat: "02/01/2006", Amount: "Mutasi", Balance: "Saldo",
+				DecimalSeparator: tt.decimal, ThousandsSeparator: tt.thousand}
+
+			rows, errs := readWithProfile(t, path, profile)
+			if len(rows) != 1 || len(errs) != 0 {
+				t.Fatalf("Expected 1 row and no errors, got %d rows and %v", len(rows), errs)
+			}
+			if rows[0].Amount != "-1500000.00" || rows[0].Balance != "8500000.00" {
+				t.Errorf("Expected amount -1500000.00 and balance 8500000.00, got %s and %s", rows[0].Amount, rows[0].Balance)
+			}
+		})
+	}
+}
+
+func TestReadBankStatementsWithProfile_MissingColumn(t *testing.T) {
+	path := writeTempCSV(t, "bca_statement_2024-03-15.csv", "unique_identifier,amount,date\nBCA_001,1.00,2024-03-15\n")
+
+	reader, err := NewReader(path)
+	if err != nil {
+		t.Fatalf("NewReader failed: %v", err)
+	}
+
+	profile := &ColumnProfile{ID: "unique_identifier", Date: "date", Debit: "debit", Credit: "credit"}
+	err = reader.ReadBankStatementsWithProfile(profile, func(*BankStatementRow, error) error { return nil })
+	if err == nil {
+		t.Error("Expected error for a profile column missing from the headers")
+	}
+}
+
+func TestColumnProfile_Validate(t *testing.T) {
+	tests := []struct {
+		name    string
+		profile ColumnProfile
+		wantErr bool
+	}{
+		{"default", *DefaultBankProfile(), false},
+		{"split", ColumnProfile{ID: "id", Date: "date", Debit: "dr", Credit: "cr"}, false},
+		{"no amount", ColumnProfile{ID: "id", Date: "date"}, true},
+		{"amount and split", ColumnProfile{ID: "id", Date: "date", Amount: "amt", Debit: "dr", Credit: "cr"}, true},
+		{"debit only", ColumnProfile{ID: "id", Date: "date", Debit: "dr"}, true},
+		{"unknown sign", ColumnProfile{ID: "id", Date: "date", Amount: "amt", Sign: "flipped"}, true},
+		{"decimal comma", ColumnProfile{ID: "id", Date: "date", Amount: "amt", DecimalSeparator: ",", ThousandsSeparator: "."}, false},
+		{"unknown decimal separator", ColumnProfile{ID: "id", Date: "date", Amount: "amt", DecimalSeparator: ";"}, true},
+		{"same separators", ColumnProfile{ID: "id", Date: "date", Amount: "amt", ThousandsSeparator: "."}, true},
+	}
+
+	for _, tt := range tests {
+		if err := tt.profile.Validate(); (err != nil) != tt.wantErr {
+			t.Errorf("%s: Validate() error = %v, wantErr %v", tt.name, err, tt.wantErr)
+		}
+	}
+}
+
+func readWithProfile(t *testing.T, path string, profile *ColumnProfile) ([]*BankStatementRow, []error) {
+	t.Helper()
+	reader, err := NewReader(path)
+	if err != nil {
+		t.Fatalf("NewReader failed: %v", err)
+	}
+
+	var rows []*BankStatementRow
+	var errs []error
+	err = reader.ReadBankStatementsWithProfile(profile, func(row *BankStatementRow, rowErr error) error {
+		if rowErr != nil {
+			errs = append(errs, rowErr)
+			return nil
+		}
+		rows = append(rows, row)
+		return nil
+	})
+	if err != nil {
+		t.Fatalf("ReadBankStatementsWithProfile failed: %v", err)
+	}
+	return rows, errs
+}
+
+func writeTempCSV(t *testing.T, name, content string) string {
+	t.Helper()
+	path := filepath.Join(t.TempDir(), name)
+	if err := os.WriteFile(path, []byte(content), 0o644); err != nil {
+		t.Fatalf("failed to write %s: %v", path, err)
+	}
+	return path
+}
@@ -5,8 +5,6 @@ import (
 	"fmt"
 	"io"
 	"os"
-	"path/filepath"
-	"strconv"
 	"strings"
 	"time"
 
@@ -21,7 +19,9 @@ type SystemTransactionRow struct {
 	Source          string
 	Type            string
 	TransactionTime string
+	Currency        string // Empty when the file has no currency column
 	RowNumber       int64
+	Record          []string // Raw fields as read, for reporting rejected rows
 }
 
 // BankStatementRow represents a row from a bank statement CSV
@@ -29,16 +29,36 @@ type BankStatementRow struct {
 	UniqueIdentifier string
 	Amount           string
 	Date             string
+	Currency         string // Empty when the file has no currency column
+	Description      string // Only filled by ReadBankStatementsWithProfile
+	Balance          string // Running balance after this row, empty when the file has none
+	ValueDate        string
+	Source           string // Bank of this row, empty when the file has no source column
+	DateFormat       string // Layout from the column profile, empty to try the common formats
 	RowNumber        int64
+	Record           []string // Raw fields as read, for reporting rejected rows
+}
+
+// RecordSource yields the rows of a tabular file one at a time, returning io.EOF after the last one.
+// *csv.Reader is one; other formats (e.g. XLSX sheets) can be read through NewRecordReader.
+type RecordSource interface {
+	Read() ([]string, error)
 }
 
 // Reader provides streaming CSV reading capabilities
 type Reader struct {
-	filePath string
-	file     *os.File
-	reader   *csv.Reader
-	headers  []string
-	rowCount int64
+	filePath   string
+	closer     io.Closer
+	reader     RecordSource
+	padShort   bool // Pad rows shorter than the header, for sources that drop trailing empty cells
+	headers    []string
+	rowCount   int64
+	headerRow  int        // 1-based line of the header row, 0 to detect it
+	footerRows int        // Number of trailing summary rows, 0 to detect them
+	preamble   [][]string // Records before the header row
+	footer     [][]string // Records after the last data row
+	held       [][]string // Records read ahead that may turn out to be footer
+	queue      [][]string // Held records released because a data row followed them
 }
 
 // NewReader creates a new CSV reader
@@ -50,6 +70,7 @@ func NewReader(filePath string) (*Reader, error) {
 
 	csvReader := csv.NewReader(file)
 	csvReader.TrimLeadingSpace = true
+	csvReader.FieldsPerRecord = -1 // Preamble and footer lines have their own widths
 
 	// Read headers
 	headers, err := csvReader.Read()
@@ -60,27 +81,51 @@ func NewReader(filePath string) (*Reader, error) {
 
 	return &Reader{
 		filePath: filePath,
-		file:     file,
+		closer:   file,
 		reader:   csvReader,
 		headers:  headers,
 		rowCount: 0,
 	}, nil
 }
 
+// NewRecordReader creates a reader over rows from another tabular format. Rows shorter than
+// the header are padded with empty cells. The source is closed with the reader if it is an io.Closer.
+func NewRecordReader(filePath string, source RecordSource) (*Reader, error) {
+	closer, _ := source.(io.Closer)
+
+	headers, err := source.Read()
+	if err != nil {
+		if closer != nil {
+			closer.Close()
+		}
+		return nil, fmt.Errorf("failed to read headers from %s: %w", filePath, err)
+	}
+
+	return &Reader{
+		filePath: filePath,
+		closer:   closer,
+		reader:   source,
+		padShort: true,
+		headers:  headers,
+		rowCount: 0,
+	}, nil
+}
+
 // ReadSystemTransactions reads system transactions in streaming fashion.
-// Validates headers, parses each row, and invokes callback for processing.
+// Validates headers (an optional trailing "currency" column is allowed), parses each row, and invokes callback for processing.
 // Errors are passed to callback allowing graceful handling and continuation.
 func (r *Reader) ReadSystemTransactions(callback func(*SystemTransactionRow, error) error) error {
 	defer r.Close()
 
 	expectedHeaders := []string{"trxID", "amount", "source", "type", "transactionTime"}
-	if !r.validateHeaders(expectedHeaders) {
-		return fmt.Errorf("invalid headers in system transaction file. Expected: %v, Got: %v",
+	if !r.locateHeader(func() bool { return r.validateHeaders(expectedHeaders, "currency") }) {
+		return fmt.Errorf("invalid headers in system transaction file. Expected: %v (optionally followed by currency), Got: %v",
 			expectedHeaders, r.headers)
 	}
 
+	// System exports have no footer, so every record after the header is a row
 	for {
-		record, err := r.reader.Read()
+		record, err := r.next(anyRecord)
 		if err == io.EOF {
 			break
 		}
@@ -88,15 +133,15 @@ func (r *Reader) ReadSystemTransactions(callback func(*SystemTransactionRow, err
 		r.rowCount++
 
 		if err != nil {
-			if cbErr := callback(nil, fmt.Errorf("row %d: failed to read: %w", r.rowCount, err)); cbErr != nil {
+			if cbErr := callback(nil, rowError(r.rowCount, record, CategoryUnreadable, fmt.Errorf("failed to read: %w", err))); cbErr != nil {
 				return cbErr
 			}
 			continue
 		}
 
-		if len(record) != len(expectedHeaders) {
-			if cbErr := callback(nil, fmt.Errorf("row %d: expected %d columns, got %d",
-				r.rowCount, len(expectedHeaders), len(record))); cbErr != nil {
+		if len(record) != len(r.headers) {
+			if cbErr := callback(nil, rowError(r.rowCount, record, CategoryColumnCount,
+				fmt.Errorf("expected %d columns, got %d", len(r.headers), len(record)))); cbErr != nil {
 				return cbErr
 			}
 			continue
@@ -109,7 +154,9 @@ func (r *Reader) ReadSystemTransactions(callback func(*SystemTransactionRow, err
 			Type:            strings.TrimSpace(record[3]),
 			TransactionTime: strings.TrimSpace(record[4]),
 			RowNumber:       r.rowCount,
+			Record:          record,
 		}
+		row.Currency = field(record, r.column("currency"))
 
 		if err := callback(row, nil); err != nil {
 			return err
@@ -120,19 +167,19 @@ func (r *Reader) ReadSystemTransactions(callback func(*SystemTransactionRow, err
 }
 
 // ReadBankStatements reads bank statement transactions in streaming fashion.
-// Validates headers, parses each row, and invokes callback for processing.
+// Validates headers (optional trailing "currency", "balance" and "source" columns are allowed), parses each row, and invokes callback for processing.
 // Errors are passed to callback allowing graceful handling and continuation.
 func (r *Reader) ReadBankStatements(callback func(*BankStatementRow, error) error) error {
 	defer r.Close()
 
 	expectedHeaders := []string{"unique_identifier", "amount", "date"}
-	if !r.validateHeaders(expectedHeaders) {
-		return fmt.Errorf("invalid headers in bank statement file. Expected: %v, Got: %v",
+	if !r.locateHeader(func() bool { return r.validateHeaders(expectedHeaders, "currency", "balance", "source") }) {
+		return fmt.Errorf("invalid headers in bank statement file. Expected: %v (optionally followed by currency, balance and source), Got: %v",
 			expectedHeaders, r.headers)
 	}
 
 	for {
-		record, err := r.reader.Read()
+		record, err := r.next(r.hasColumn(0))
 		if err == io.EOF {
 			break
 		}
@@ -140,15 +187,15 @@ func (r *Reader) ReadBankStatements(callback func(*BankStatementRow, error) erro
 		r.rowCount++
 
 		if err != nil {
-			if cbErr := callback(nil, fmt.Errorf("row %d: failed to read: %w", r.rowCount, err)); cbErr != nil {
+			if cbErr := callback(nil, rowError(r.rowCount, record, CategoryUnreadable, fmt.Errorf("failed to read: %w", err))); cbErr != nil {
 				return cbErr
 			}
 			continue
 		}
 
-		if len(record) != len(expectedHeaders) {
-			if cbErr := callback(nil, fmt.Errorf("row %d: expected %d columns, got %d",
-				r.rowCount, len(expectedHeaders), len(record))); cbErr != nil {
+		if len(record) != len(r.headers) {
+			if cbErr := callback(nil, rowError(r.rowCount, record, CategoryColumnCount,
+				fmt.Errorf("expected %d columns, got %d", len(r.headers), len(record)))); cbErr != nil {
 				return cbErr
 			}
 			continue
@@ -159,6 +206,89 @@ func (r *Reader) ReadBankStatements(callback func(*BankStatementRow, error) erro
 			Amount:           strings.TrimSpace(record[1]),
 			Date:             strings.TrimSpace(record[2]),
 			RowNumber:        r.rowCount,
+			Record:           record,
+		}
+		row.Currency = field(record, r.column("currency"))
+		row.Balance = field(record, r.column("balance"))
+		row.Source = field(record, r.column("source"))
+
+		if err := callback(row, nil); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// ReadBankStatementsWithProfile reads a bank export whose layout is described by a column profile.
+// Columns are looked up by header name, so extra columns and any column order are allowed.
+// Amounts are normalized to the signed convention (debits negative) before reaching the callback.
+func (r *Reader) ReadBankStatementsWithProfile(profile *ColumnProfile, callback func(*BankStatementRow, error) error) error {
+	defer r.Close()
+
+	if err := profile.Validate(); err != nil {
+		return fmt.Errorf("invalid column profile: %w", err)
+	}
+
+	r.headerRow = profile.HeaderRow
+	r.footerRows = profile.FooterRows
+
+	var idx *columnIndex
+	var resolveErr error
+	found := r.locateHeader(func() bool {
+		idx, resolveErr = profile.resolveColumns(r.headers)
+		return resolveErr == nil
+	})
+	if !found {
+		if resolveErr == nil {
+			resolveErr = fmt.Errorf("header row %d not found", profile.HeaderRow)
+		}
+		return fmt.Errorf("bank statement file does not match column profile: %w", resolveErr)
+	}
+
+	for {
+		record, err := r.next(r.hasColumn(idx.id))
+		if err == io.EOF {
+			break
+		}
+
+		r.rowCount++
+
+		if err != nil {
+			if cbErr := callback(nil, rowError(r.rowCount, record, CategoryUnreadable, fmt.Errorf("failed to read: %w", err))); cbErr != nil {
+				return cbErr
+			}
+			continue
+		}
+
+		if len(record) != len(r.headers) {
+			if cbErr := callback(nil, rowError(r.rowCount, record, CategoryColumnCount,
+				fmt.Errorf("expected %d columns, got %d", len(r.headers), len(record)))); cbErr != nil {
+				return cbErr
+			}
+			continue
+		}
+
+		amount, err := profile.signedAmount(record, idx)
+		if err != nil {
+			if cbErr := callback(nil, rowError(r.rowCount, record, CategoryBadAmount, err)); cbErr != nil {
+				return cbErr
+			}
+			continue
+		}
+
+		row := &BankStatementRow{
+			UniqueIdentifier: field(record, idx.id),
+			Amount:           amount,
+			Date:             field(record, idx.date),
+			Currency:         field(record, idx.currency),
+			Description:      field(record, idx.description),
+			Balance:          profile.Number(field(record, idx.balance)),
+			ValueDate:        field(record, idx.valueDate),
+			Source:           field(record, idx.source),
+			DateFormat:       profile.DateFormat,
+			RowNumber:        r.rowCount,
+			Record:           record,
 		}
 
 		if err := callback(row, nil); err != nil {
@@ -171,46 +301,77 @@ func (r *Reader) ReadBankStatements(callback func(*BankStatementRow, error) erro
 
 // Close closes the underlying file
 func (r *Reader) Close() error {
-	if r.file != nil {
-		return r.file.Close()
+	if r.closer != nil {
+		return r.closer.Close()
 	}
 	return nil
 }
 
-// validateHeaders checks if the actual headers match expected (case-insensitive)
-func (r *Reader) validateHeaders(expected []string) bool {
-	if len(r.headers) != len(expected) {
+// validateHeaders checks if the actual headers start with expected (case-insensitive),
+// followed by any of the optional headers, each at most once and in any order
+func (r *Reader) validateHeaders(expected []string, optional ...string) bool {
+	if len(r.headers) < len(expected) || len(r.headers) > len(expected)+len(optional) {
 		return false
 	}
 
-	for i, header := range r.headers {
-		if !strings.EqualFold(header, expected[i]) {
+	for i, header := range expected {
+		if !strings.EqualFold(r.headers[i], header) {
+			return false
+		}
+	}
+
+	seen := make(map[string]bool)
+	for _, header := range r.headers[len(expected):] {
+		name := strings.ToLower(header)
+		if seen[name] || !containsFold(optional, header) {
 			return false
 		}
+		seen[name] = true
 	}
 
 	return true
 }
 
+// column returns the position of an optional header, or -1 when the file does not have it
+func (r *Reader) column(name string) int {
+	for i, header := range r.headers {
+		if strings.EqualFold(header, name) {
+			return i
+		}
+	}
+	return -1
+}
+
+func containsFold(values []string, s string) bool {
+	for _, v := range values {
+		if strings.EqualFold(v, s) {
+			return true
+		}
+	}
+	return false
+}
+
 // ParseSystemTransaction converts a SystemTransactionRow to a Transaction entity.
 // Parses and validates amount, type (DEBIT/CREDIT), and timestamp (RFC3339 format).
 // Stores raw data for audit and normalizes amount based on transaction type.
+// Errors are *RowError, categorized by the field that is invalid.
 func ParseSystemTransaction(row *SystemTransactionRow, jobID, fileID string) (*transaction.Transaction, error) {
-	amount, err := strconv.ParseFloat(row.Amount, 64)
+	amount, category, err := parseAmount(row.Amount, row.Currency)
 	if err != nil {
-		return nil, fmt.Errorf("invalid amount %q: %w", row.Amount, err)
+		return nil, rowError(row.RowNumber, row.Record, category, err)
 	}
 
 	txnType := domain.TransactionTypeCredit
 	if strings.ToUpper(row.Type) == "DEBIT" {
 		txnType = domain.TransactionTypeDebit
 	} else if strings.ToUpper(row.Type) != "CREDIT" {
-		return nil, fmt.Errorf("invalid transaction type %q", row.Type)
+		return nil, rowError(row.RowNumber, row.Record, CategoryBadType, fmt.Errorf("invalid transaction type %q", row.Type))
 	}
 
 	txnTime, err := time.Parse(time.RFC3339, row.TransactionTime)
 	if err != nil {
-		return nil, fmt.Errorf("invalid transaction time %q: %w", row.TransactionTime, err)
+		return nil, rowError(row.RowNumber, row.Record, CategoryBadDate,
+			fmt.Errorf("invalid transaction time %q: %w", row.TransactionTime, err))
 	}
 
 	txn := transaction.NewTransaction(
@@ -230,6 +391,7 @@ func ParseSystemTransaction(row *SystemTransactionRow, jobID, fileID string) (*t
 		"source":          row.Source,
 		"type":            row.Type,
 		"transactionTime": row.TransactionTime,
+		"currency":        row.Currency,
 		"rowNumber":       row.RowNumber,
 	}
 
@@ -239,21 +401,32 @@ func ParseSystemTransaction(row *SystemTransactionRow, jobID, fileID string) (*t
 
 // ParseBankTransaction converts a BankStatementRow to a Transaction entity.
 // Parses amount and date, determines transaction type from amount sign (negative=debit).
+// The row's own source, when the file has a source column, takes precedence over bankSource.
 // Stores raw data for audit and normalizes amount.
+// Errors are *RowError, categorized by the field that is invalid.
 func ParseBankTransaction(row *BankStatementRow, jobID, fileID, bankSource string) (*transaction.Transaction, error) {
-	amount, err := strconv.ParseFloat(row.Amount, 64)
+	if row.Source != "" {
+		bankSource = row.Source
+	}
+	if bankSource == "" {
+		return nil, rowError(row.RowNumber, row.Record, CategoryNoSource,
+			fmt.Errorf("row has no source and the bank of the file is unknown"))
+	}
+	bankSource = strings.ToUpper(bankSource)
+
+	amount, category, err := parseAmount(row.Amount, row.Currency)
 	if err != nil {
-		return nil, fmt.Errorf("invalid amount %q: %w", row.Amount, err)
+		return nil, rowError(row.RowNumber, row.Record, category, err)
 	}
 
 	txnType := domain.TransactionTypeCredit
-	if amount < 0 {
+	if amount.IsNegative() {
 		txnType = domain.TransactionTypeDebit
 	}
 
-	txnDate, err := parseDate(row.Date)
+	txnDate, err := parseDateWithFormat(row.Date, row.DateFormat)
 	if err != nil {
-		return nil, fmt.Errorf("invalid date %q: %w", row.Date, err)
+		return nil, rowError(row.RowNumber, row.Record, CategoryBadDate, fmt.Errorf("invalid date %q: %w", row.Date, err))
 	}
 
 	txn := transaction.NewTransaction(
@@ -263,7 +436,7 @@ func ParseBankTransaction(row *BankStatementRow, jobID, fileID, bankSource strin
 		txnDate,
 		amount,
 		txnType,
-		strings.ToUpper(bankSource),
+		bankSource,
 	)
 	txn.ID = row.UniqueIdentifier
 
@@ -271,6 +444,10 @@ func ParseBankTransaction(row *BankStatementRow, jobID, fileID, bankSource strin
 		"unique_identifier": row.UniqueIdentifier,
 		"amount":            row.Amount,
 		"date":              row.Date,
+		"currency":          row.Currency,
+		"description":       row.Description,
+		"balance":           row.Balance,
+		"valueDate":         row.ValueDate,
 		"bankSource":        bankSource,
 		"rowNumber":         row.RowNumber,
 	}
@@ -279,6 +456,50 @@ func ParseBankTransaction(row *BankStatementRow, jobID, fileID, bankSource strin
 	return txn, nil
 }
 
+// ParseBalance parses the running balance of a row in the given currency, returning nil when the row has none
+func ParseBalance(row *BankStatementRow, currency domain.Currency) (*domain.Money, error) {
+	if row.Balance == "" {
+		return nil, nil
+	}
+
+	balance, err := domain.ParseMoney(row.Balance, currency)
+	if err != nil {
+		return nil, fmt.Errorf("invalid balance: %w", err)
+	}
+	return &balance, nil
+}
+
+// parseAmount parses a row amount in the row's currency. An unknown currency is told
+// apart from a malformed amount so that rejected rows are reported under the right category.
+func parseAmount(amount, currency string) (domain.Money, ErrorCategory, error) {
+	cur := parseCurrency(currency)
+	if _, err := domain.RuleFor(cur); err != nil {
+		return domain.Money{}, CategoryBadCurrency, err
+	}
+
+	money, err := domain.ParseMoney(amount, cur)
+	if err != nil {
+		return domain.Money{}, CategoryBadAmount, err
+	}
+	return money, "", nil
+}
+
+// parseCurrency returns the currency of a row, falling back to the default when the column is absent or empty
+func parseCurrency(currency string) domain.Currency {
+	if strings.TrimSpace(currency) == "" {
+		return domain.DefaultCurrency
+	}
+	return domain.Currency(strings.ToUpper(strings.TrimSpace(currency)))
+}
+
+// parseDateWithFormat parses a date with the given layout, or any of the common formats when it is empty
+func parseDateWithFormat(dateStr, layout string) (time.Time, error) {
+	if layout == "" {
+		return parseDate(dateStr)
+	}
+	return time.Parse(layout, dateStr)
+}
+
 // parseDate parses various date formats
 func parseDate(dateStr string) (time.Time, error) {
 	formats := []string{
@@ -298,22 +519,3 @@ func parseDate(dateStr string) (time.Time, error) {
 
 	return time.Time{}, fmt.Errorf("unable to parse date: %s", dateStr)
 }
-
-// ExtractBankSourceFromFilename extracts the bank source from a filename.
-// Expected format: {bank_name}_statement_{date}.csv
-// Example: mandiri_statement_2024-03-15.csv returns "MANDIRI"
-func ExtractBankSourceFromFilename(filePath string) (string, error) {
-	filename := filepath.Base(filePath)
-	parts := strings.Split(filename, "_")
-
-	if len(parts) < 2 {
-		return "", fmt.Errorf("invalid bank statement filename format: %s", filename)
-	}
-
-	bankName := strings.ToUpper(parts[0])
-	if bankName == "" {
-		return "", fmt.Errorf("could not extract bank name from filename: %s", filename)
-	}
-
-	return bankName, nil
-}
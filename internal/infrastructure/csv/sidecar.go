@@ -0,0 +1,64 @@
+package csv
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/farhaan/amartha-reconcile-system/internal/domain"
+	"github.com/farhaan/amartha-reconcile-system/internal/domain/statement"
+)
+
+// BalanceSidecar holds statement balances supplied next to an export that does not state them
+type BalanceSidecar struct {
+	OpeningBalance string `json:"opening_balance"`
+	ClosingBalance string `json:"closing_balance"`
+	Currency       string `json:"currency,omitempty"`
+}
+
+// SidecarPath returns where the balance sidecar of a statement file lives,
+// e.g. bca_statement_2024-03-15.csv -> bca_statement_2024-03-15.balances.json
+func SidecarPath(statementPath string) string {
+	return strings.TrimSuffix(statementPath, filepath.Ext(statementPath)) + ".balances.json"
+}
+
+// ApplyBalanceSidecar overrides the statement's balances with its sidecar file, if there is one.
+// It returns false when no sidecar exists.
+func ApplyBalanceSidecar(stmt *statement.Statement) (bool, error) {
+	path := SidecarPath(stmt.FilePath)
+	data, err := os.ReadFile(path)
+	if errors.Is(err, fs.ErrNotExist) {
+		return false, nil
+	}
+	if err != nil {
+		return false, fmt.Errorf("failed to open file %s: %w", path, err)
+	}
+
+	var sidecar BalanceSidecar
+	if err := json.Unmarshal(data, &sidecar); err != nil {
+		return false, fmt.Errorf("failed to parse balance sidecar %s: %w", path, err)
+	}
+
+	currency := stmt.Currency
+	if sidecar.Currency != "" {
+		currency = parseCurrency(sidecar.Currency)
+	}
+
+	opening, err := domain.ParseMoney(sidecar.OpeningBalance, currency)
+	if err != nil {
+		return false, fmt.Errorf("%s: invalid opening balance: %w", path, err)
+	}
+	closing, err := domain.ParseMoney(sidecar.ClosingBalance, currency)
+	if err != nil {
+		return false, fmt.Errorf("%s: invalid closing balance: %w", path, err)
+	}
+
+	stmt.Currency = currency
+	stmt.OpeningBalance = &opening
+	stmt.ClosingBalance = &closing
+	return true, nil
+}
@@ -0,0 +1,240 @@
+package csv
+
+import (
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/farhaan/amartha-reconcile-system/internal/domain"
+	"github.com/farhaan/amartha-reconcile-system/internal/domain/statement"
+)
+
+// maxPreambleRows caps how far down the file the header row is searched for
+const maxPreambleRows = 20
+
+// Metadata labels recognised in preamble and footer lines, in English and Indonesian
+var (
+	accountLabels        = []string{"account number", "account no", "account", "nomor rekening", "no. rekening", "no rekening", "rekening"}
+	periodLabels         = []string{"period", "periode"}
+	currencyLabels       = []string{"currency", "mata uang"}
+	openingBalanceLabels = []string{"opening balance", "beginning balance", "saldo awal"}
+	closingBalanceLabels = []string{"closing balance", "ending balance", "saldo akhir"}
+
+	// summaryPrefixes start the labels of total lines under the table, e.g. "Total Debet"
+	summaryPrefixes = []string{"total", "subtotal", "grand total", "jumlah"}
+)
+
+// locateHeader moves past preamble lines until match accepts r.headers. With a known
+// header row it skips straight to that row, otherwise it tries each of the first
+// maxPreambleRows records. Skipped records are kept as preamble for Metadata.
+func (r *Reader) locateHeader(match func() bool) bool {
+	if r.headerRow > 0 {
+		for line := 1; line < r.headerRow; line++ {
+			if !r.advanceHeader() {
+				return false
+			}
+		}
+		return match()
+	}
+
+	first := r.headers
+	for line := 1; line <= maxPreambleRows; line++ {
+		if match() {
+			return true
+		}
+		if !r.advanceHeader() {
+			break
+		}
+	}
+
+	r.headers = first
+	return false
+}
+
+// advanceHeader moves the current header candidate into the preamble and reads the next record
+func (r *Reader) advanceHeader() bool {
+	record, err := r.reader.Read()
+	if err != nil {
+		return false
+	}
+	r.preamble = append(r.preamble, r.headers)
+	r.headers = record
+	return true
+}
+
+// next returns the next record of the data table, or io.EOF after the last one.
+// With a known footer size the last footerRows records are the footer.
+// Otherwise, in files that had a preamble, records that isData rejects are held
+// until a data record follows them, in which case they are returned as ordinary
+// rows. At the end of the file held records that read as metadata (or are blank)
+// become the footer and the rest are returned as rows, so the caller rejects them
+// rather than losing them. Files without a preamble get no footer detection.
+func (r *Reader) next(isData func([]string) bool) ([]string, error) {
+	for {
+		if len(r.queue) > 0 {
+			record := r.queue[0]
+			r.queue = r.queue[1:]
+			return record, nil
+		}
+
+		record, err := r.reader.Read()
+		if err == io.EOF {
+			for _, held := range r.held {
+				if r.footerRows > 0 || isFooterLine(held) {
+					r.footer = append(r.footer, held)
+				} else {
+					r.queue = append(r.queue, held)
+				}
+			}
+			r.held = nil
+			if len(r.queue) > 0 {
+				continue
+			}
+			return nil, io.EOF
+		}
+		if err != nil {
+			return nil, err
+		}
+		if r.padShort && len(record) < len(r.headers) {
+			record = append(record, make([]string, len(r.headers)-len(record))...)
+		}
+
+		if r.footerRows > 0 {
+			r.held = append(r.held, record)
+			if len(r.held) > r.footerRows {
+				record, r.held = r.held[0], r.held[1:]
+				return record, nil
+			}
+			continue
+		}
+
+		if len(r.preamble) > 0 && !isData(record) {
+			r.held = append(r.held, record)
+			continue
+		}
+		if len(r.held) > 0 {
+			r.queue = append(r.held, record)
+			r.held = nil
+			continue
+		}
+		return record, nil
+	}
+}
+
+// hasColumn returns a data row check: the row is as wide as the header and column i is filled
+func (r *Reader) hasColumn(i int) func([]string) bool {
+	return func(record []string) bool {
+		return len(record) == len(r.headers) && strings.TrimSpace(record[i]) != ""
+	}
+}
+
+// anyRecord treats every record as a data row, disabling footer detection
+func anyRecord([]string) bool {
+	return true
+}
+
+// isFooterLine reports whether a record left after the data table is blank, a total
+// line or one of the metadata lines ParseStatement reads, e.g. "Saldo Akhir,1099.50"
+func isFooterLine(record []string) bool {
+	label, _, ok := metadataLine(record)
+	if !ok {
+		return strings.TrimSpace(strings.Join(record, "")) == ""
+	}
+	for _, labels := range [][]string{accountLabels, periodLabels, currencyLabels, openingBalanceLabels, closingBalanceLabels} {
+		for _, known := range labels {
+			if label == known {
+				return true
+			}
+		}
+	}
+	for _, prefix := range summaryPrefixes {
+		if strings.HasPrefix(label, prefix) {
+			return true
+		}
+	}
+	return false
+}
+
+// metadataLine splits a preamble or footer record into a lower-cased label and its value
+func metadataLine(record []string) (label, value string, ok bool) {
+	cells := make([]string, 0, len(record))
+	for _, cell := range record {
+		if cell = strings.TrimSpace(cell); cell != "" {
+			cells = append(cells, cell)
+		}
+	}
+
+	switch {
+	case len(cells) >= 2:
+		label, value = cells[0], cells[1]
+	case len(cells) == 1 && strings.Contains(cells[0], ":"):
+		label, value, _ = strings.Cut(cells[0], ":")
+	default:
+		return "", "", false
+	}
+
+	label = strings.ToLower(strings.TrimSuffix(strings.TrimSpace(label), ":"))
+	return label, strings.TrimSpace(value), true
+}
+
+// Metadata returns the label/value pairs found in the preamble and footer, e.g.
+// "Saldo Awal,1000.00" or "Account No: 1234567890". Labels are lower-cased and
+// stripped of a trailing colon. Only available after the rows have been read.
+func (r *Reader) Metadata() map[string]string {
+	metadata := make(map[string]string)
+	for _, record := range append(append([][]string{}, r.preamble...), r.footer...) {
+		if label, value, ok := metadataLine(record); ok {
+			metadata[label] = value
+		}
+	}
+	return metadata
+}
+
+// ParseStatement builds a statement from preamble and footer metadata.
+// Balances are parsed in the currency named by the metadata, or the given one.
+func ParseStatement(metadata map[string]string, filePath, bankSource string, currency domain.Currency) (*statement.Statement, error) {
+	if value := lookupLabel(metadata, currencyLabels); value != "" {
+		currency = parseCurrency(value)
+	}
+
+	stmt := statement.NewStatement(strings.ToUpper(bankSource), filePath, currency)
+	for label, value := range metadata {
+		stmt.Metadata[label] = value
+	}
+	stmt.AccountNumber = lookupLabel(metadata, accountLabels)
+	stmt.Period = lookupLabel(metadata, periodLabels)
+
+	var err error
+	if stmt.OpeningBalance, err = parseBalance(metadata, openingBalanceLabels, currency); err != nil {
+		return nil, fmt.Errorf("invalid opening balance: %w", err)
+	}
+	if stmt.ClosingBalance, err = parseBalance(metadata, closingBalanceLabels, currency); err != nil {
+		return nil, fmt.Errorf("invalid closing balance: %w", err)
+	}
+
+	return stmt, nil
+}
+
+// lookupLabel returns the value of the first label present in the metadata
+func lookupLabel(metadata map[string]string, labels []string) string {
+	for _, label := range labels {
+		if value, ok := metadata[label]; ok {
+			return value
+		}
+	}
+	return ""
+}
+
+// parseBalance parses the balance under one of the labels, returning nil when it is absent
+func parseBalance(metadata map[string]string, labels []string, currency domain.Currency) (*domain.Money, error) {
+	value := lookupLabel(metadata, labels)
+	if value == "" {
+		return nil, nil
+	}
+
+	balance, err := domain.ParseMoney(value, currency)
+	if err != nil {
+		return nil, err
+	}
+	return &balance, nil
+}
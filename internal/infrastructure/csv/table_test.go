@@ -0,0 +1,254 @@
+package csv
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/farhaan/amartha-reconcile-system/internal/domain"
+)
+
+func TestReadBankStatements_PreambleAndFooter(t *testing.T) {
+	path := writeTempCSV(t, "bca_statement_2024-03-15.csv",
+		"Account No:,0123456789\n"+
+			"Period,2024-03-15 - 2024-03-22\n"+
+			"Opening Balance,1000.00\n"+
+			"\n"+
+			"unique_identifier,amount,date\n"+
+			"BCA_001,-150.50,2024-03-15\n"+
+			",,\n"+
+			"BCA_002,250.00,2024-03-16\n"+
+			"\n"+
+			"Closing Balance,1099.50\n")
+
+	reader, err := NewReader(path)
+	if err != nil {
+		t.Fatalf("NewReader failed: %v", err)
+	}
+
+	var rows []*BankStatementRow
+	err = reader.ReadBankStatements(func(row *BankStatementRow, rowErr error) error {
+		if rowErr != nil {
+			t.Errorf("Unexpected row error: %v", rowErr)
+			return nil
+		}
+		rows = append(rows, row)
+		return nil
+	})
+	if err != nil {
+		t.Fatalf("ReadBankStatements failed: %v", err)
+	}
+
+	// The blank row between data rows is passed on, the footer is not
+	if len(rows) != 3 || rows[0].UniqueIdentifier != "BCA_001" || rows[2].UniqueIdentifier != "BCA_002" {
+		t.Fatalf("Expected BCA_001, blank row and BCA_002, got %d rows", len(rows))
+	}
+
+	stmt, err := ParseStatement(reader.Metadata(), path, "bca", domain.DefaultCurrency)
+	if err != nil {
+		t.Fatalf("ParseStatement failed: %v", err)
+	}
+
+	if stmt.Source != "BCA" || stmt.AccountNumber != "0123456789" || stmt.Period != "2024-03-15 - 2024-03-22" {
+		t.Errorf("Unexpected statement details: %+v", stmt)
+	}
+	if !stmt.HasBalances() {
+		t.Fatal("Expected opening and closing balances")
+	}
+	if stmt.OpeningBalance.Minor != 100000 || stmt.ClosingBalance.Minor != 109950 {
+		t.Errorf("Expected balances 1000.00 and 1099.50, got %s and %s", stmt.OpeningBalance, stmt.ClosingBalance)
+	}
+}
+
+func TestReadBankStatements_TruncatedLastRow(t *testing.T) {
+	tests := []struct {
+		name    string
+		content string
+	}{
+		{
+			name:    "no preamble",
+			content: "unique_identifier,amount,date\nBCA_001,-150.50,2024-03-15\nBCA_002,1000.00\n",
+		},
+		{
+			name: "preamble and footer",
+			content: "Account No:,0123456789\n" +
+				"unique_identifier,amount,date\n" +
+				"BCA_001,-150.50,2024-03-15\n" +
+				"BCA_002,1000.00\n" +
+				"Total Debet,150.50\n" +
+				"Closing Balance,849.50\n",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			path := writeTempCSV(t, "bca_statement_2024-03-15.csv", tt.content)
+
+			reader, err := NewReader(path)
+			if err != nil {
+				t.Fatalf("NewReader failed: %v", err)
+			}
+
+			var rows []*BankStatementRow
+			var rowErrs []*RowError
+			err = reader.ReadBankStatements(func(row *BankStatementRow, rowErr error) error {
+				if rowErr != nil {
+					var re *RowError
+					if !errors.As(rowErr, &re) {
+						t.Fatalf("Expected a RowError, got %v", rowErr)
+					}
+					rowErrs = append(rowErrs, re)
+					return nil
+				}
+				rows = append(rows, row)
+				return nil
+			})
+			if err != nil {
+				t.Fatalf("ReadBankStatements failed: %v", err)
+			}
+
+			if len(rows) != 1 {
+				t.Errorf("Expected 1 row, got %d", len(rows))
+			}
+			// The truncated row is rejected, not taken for a footer
+			if len(rowErrs) != 1 || rowErrs[0].Category != CategoryColumnCount || rowErrs[0].Row != 2 {
+				t.Fatalf("Expected row 2 rejected as %s, got %+v", CategoryColumnCount, rowErrs)
+			}
+		})
+	}
+}
+
+func TestReadBankStatementsWithProfile_KnownTableBounds(t *testing.T) {
+	path := writeTempCSV(t, "bni_statement_2024-03-15.csv",
+		"PT BANK NEGARA INDONESIA\n"+
+			"Saldo Awal: 5000.00\n"+
+			"Tanggal,Ref No,Mutasi\n"+
+			"17/03/2024,BNI_001,-500.75\n"+
+			"18/03/2024,BNI_002,1500.50\n"+
+			"Saldo Akhir,,5999.75\n")
+
+	profile := &ColumnProfile{
+		ID:         "Ref No",
+		Date:       "Tanggal",
+		DateFormat: "02/01/2006",
+		Amount:     "Mutasi",
+		HeaderRow:  3,
+		FooterRows: 1,
+	}
+
+	reader, err := NewReader(path)
+	if err != nil {
+		t.Fatalf("NewReader failed: %v", err)
+	}
+
+	var rows []*BankStatementRow
+	err = reader.ReadBankStatementsWithProfile(profile, func(row *BankStatementRow, rowErr error) error {
+		if rowErr != nil {
+			t.Errorf("Unexpected row error: %v", rowErr)
+			return nil
+		}
+		rows = append(rows, row)
+		return nil
+	})
+	if err != nil {
+		t.Fatalf("ReadBankStatementsWithProfile failed: %v", err)
+	}
+
+	if len(rows) != 2 {
+		t.Fatalf("Expected 2 rows, got %d", len(rows))
+	}
+
+	stmt, err := ParseStatement(reader.Metadata(), path, "BNI", domain.DefaultCurrency)
+	if err != nil {
+		t.Fatalf("ParseStatement failed: %v", err)
+	}
+	if !stmt.HasBalances() || stmt.OpeningBalance.Minor != 500000 || stmt.ClosingBalance.Minor != 599975 {
+		t.Errorf("Expected balances 5000.00 and 5999.75, got %v and %v", stmt.OpeningBalance, stmt.ClosingBalance)
+	}
+}
+
+func TestReadBankStatements_HeaderNotFound(t *testing.T) {
+	path := writeTempCSV(t, "bca_statement_2024-03-15.csv", "id,value,day\nBCA_001,1.00,2024-03-15\n")
+
+	reader, err := NewReader(path)
+	if err != nil {
+		t.Fatalf("NewReader failed: %v", err)
+	}
+
+	err = reader.ReadBankStatements(func(*BankStatementRow, error) error { return nil })
+	if err == nil {
+		t.Error("Expected error when no header row matches")
+	}
+}
+
+func TestReadBankStatements_OptionalColumnsInAnyOrder(t *testing.T) {
+	path := writeTempCSV(t, "bca_statement_2024-03-15.csv",
+		"unique_identifier,amount,date,balance,currency\n"+
+			"BCA_001,-150.50,2024-03-15,849.50,IDR\n")
+
+	reader, err := NewReader(path)
+	if err != nil {
+		t.Fatalf("NewReader failed: %v", err)
+	}
+
+	var rows []*BankStatementRow
+	err = reader.ReadBankStatements(func(row *BankStatementRow, rowErr error) error {
+		if rowErr != nil {
+			t.Errorf("Unexpected row error: %v", rowErr)
+			return nil
+		}
+		rows = append(rows, row)
+		return nil
+	})
+	if err != nil {
+		t.Fatalf("ReadBankStatements failed: %v", err)
+	}
+
+	if len(rows) != 1 || rows[0].Balance != "849.50" || rows[0].Currency != "IDR" {
+		t.Fatalf("Expected balance 849.50 and currency IDR, got %+v", rows)
+	}
+
+	balance, err := ParseBalance(rows[0], domain.CurrencyIDR)
+	if err != nil || balance == nil || balance.Minor != 84950 {
+		t.Errorf("Expected parsed balance 849.50, got %v (%v)", balance, err)
+	}
+}
+
+func TestReadBankStatements_SourceColumn(t *testing.T) {
+	path := writeTempCSV(t, "combined_statement_2024-03-15.csv",
+		"unique_identifier,amount,date,source\n"+
+			"BCA_001,-150.50,2024-03-15,bca\n"+
+			"X_001,10.00,2024-03-15,\n")
+
+	reader, err := NewReader(path)
+	if err != nil {
+		t.Fatalf("NewReader failed: %v", err)
+	}
+
+	var rows []*BankStatementRow
+	err = reader.ReadBankStatements(func(row *BankStatementRow, rowErr error) error {
+		if rowErr != nil {
+			t.Errorf("Unexpected row error: %v", rowErr)
+			return nil
+		}
+		rows = append(rows, row)
+		return nil
+	})
+	if err != nil {
+		t.Fatalf("ReadBankStatements failed: %v", err)
+	}
+	if len(rows) != 2 {
+		t.Fatalf("Expected 2 rows, got %d", len(rows))
+	}
+
+	txn, err := ParseBankTransaction(rows[0], "job", "file", "MANDIRI")
+	if err != nil {
+		t.Fatalf("ParseBankTransaction failed: %v", err)
+	}
+	if txn.Source != "BCA" {
+		t.Errorf("Expected the row source BCA to win over the file's bank, got %s", txn.Source)
+	}
+
+	if _, err := ParseBankTransaction(rows[1], "job", "file", ""); err == nil {
+		t.Error("Expected error for a row without source in a file without bank")
+	}
+}
@@ -0,0 +1,123 @@
+package fxrates
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/farhaan/amartha-reconcile-system/internal/domain"
+	"github.com/farhaan/amartha-reconcile-system/internal/domain/fx"
+)
+
+// RateRow represents one dated exchange rate from a rates file
+type RateRow struct {
+	Date string      `json:"date"`
+	From string      `json:"from"`
+	To   string      `json:"to"`
+	Rate json.Number `json:"rate"`
+}
+
+// LoadRateTable reads an FX rate file, picking the format from the extension.
+// CSV files need a "date,from,to,rate" header, JSON files hold an array of
+// {"date": "2024-03-15", "from": "USD", "to": "IDR", "rate": "15650.25"} objects.
+// A date and currency pair may only be listed once.
+func LoadRateTable(filePath string) (*fx.RateTable, error) {
+	file, err := os.Open(filePath)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open file %s: %w", filePath, err)
+	}
+	defer file.Close()
+
+	var rows []RateRow
+	switch strings.ToLower(filepath.Ext(filePath)) {
+	case ".csv":
+		rows, err = readCSVRates(file)
+	case ".json":
+		rows, err = readJSONRates(file)
+	default:
+		return nil, fmt.Errorf("unsupported FX rate file %s: expected .csv or .json", filePath)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to read FX rates from %s: %w", filePath, err)
+	}
+
+	table := fx.NewRateTable()
+	seen := make(map[string]bool)
+	for i, row := range rows {
+		date, err := time.Parse("2006-01-02", strings.TrimSpace(row.Date))
+		if err != nil {
+			return nil, fmt.Errorf("rate %d: invalid date %q: %w", i+1, row.Date, err)
+		}
+
+		from := domain.Currency(strings.ToUpper(strings.TrimSpace(row.From)))
+		to := domain.Currency(strings.ToUpper(strings.TrimSpace(row.To)))
+		if from == "" || to == "" {
+			return nil, fmt.Errorf("rate %d: missing currency, from %q to %q", i+1, from, to)
+		}
+
+		key := date.Format("2006-01-02") + "_" + string(from) + "_" + string(to)
+		if seen[key] {
+			return nil, fmt.Errorf("rate %d: duplicate %s/%s rate on %s", i+1, from, to, date.Format("2006-01-02"))
+		}
+		seen[key] = true
+
+		if err := table.AddRate(date, from, to, strings.TrimSpace(row.Rate.String())); err != nil {
+			return nil, fmt.Errorf("rate %d: %w", i+1, err)
+		}
+	}
+
+	return table, nil
+}
+
+func readCSVRates(r io.Reader) ([]RateRow, error) {
+	csvReader := csv.NewReader(r)
+	csvReader.TrimLeadingSpace = true
+
+	headers, err := csvReader.Read()
+	if err != nil {
+		return nil, fmt.Errorf("failed to read headers: %w", err)
+	}
+
+	expectedHeaders := []string{"date", "from", "to", "rate"}
+	if len(headers) != len(expectedHeaders) {
+		return nil, fmt.Errorf("invalid headers. Expected: %v, Got: %v", expectedHeaders, headers)
+	}
+	for i, header := range headers {
+		if !strings.EqualFold(strings.TrimSpace(header), expectedHeaders[i]) {
+			return nil, fmt.Errorf("invalid headers. Expected: %v, Got: %v", expectedHeaders, headers)
+		}
+	}
+
+	rows := make([]RateRow, 0)
+	for {
+		record, err := csvReader.Read()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return nil, err
+		}
+
+		rows = append(rows, RateRow{
+			Date: record[0],
+			From: record[1],
+			To:   record[2],
+			Rate: json.Number(strings.TrimSpace(record[3])),
+		})
+	}
+
+	return rows, nil
+}
+
+func readJSONRates(r io.Reader) ([]RateRow, error) {
+	rows := make([]RateRow, 0)
+	if err := json.NewDecoder(r).Decode(&rows); err != nil {
+		return nil, err
+	}
+	return rows, nil
+}
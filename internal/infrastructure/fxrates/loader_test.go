@@ -0,0 +1,152 @@
+package fxrates
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/farhaan/amartha-reconcile-system/internal/domain"
+)
+
+func TestLoadRateTable(t *testing.T) {
+	tests := []struct {
+		name    string
+		file    string
+		content string
+		wantErr string // Empty when the file should load
+	}{
+		{
+			name:    "valid CSV",
+			file:    "rates.csv",
+			content: "date,from,to,rate\n2024-03-15,USD,IDR,15650.25\n2024-03-18, usd , idr ,15610.50\n",
+		},
+		{
+			name:    "valid JSON",
+			file:    "rates.json",
+			content: `[{"date": "2024-03-15", "from": "USD", "to": "IDR", "rate": "15650.25"}, {"date": "2024-03-18", "from": "USD", "to": "IDR", "rate": 15610.50}]`,
+		},
+		{
+			name:    "CSV header in any case",
+			file:    "rates.csv",
+			content: "Date,From,To,Rate\n2024-03-15,USD,IDR,15650.25\n",
+		},
+		{
+			name:    "wrong CSV headers",
+			file:    "rates.csv",
+			content: "day,from,to,rate\n2024-03-15,USD,IDR,15650.25\n",
+			wantErr: "invalid headers",
+		},
+		{
+			name:    "non-numeric rate",
+			file:    "rates.csv",
+			content: "date,from,to,rate\n2024-03-15,USD,IDR,abc\n",
+			wantErr: `rate 1: invalid rate "abc"`,
+		},
+		{
+			name:    "negative rate",
+			file:    "rates.json",
+			content: `[{"date": "2024-03-15", "from": "USD", "to": "IDR", "rate": "-15650.25"}]`,
+			wantErr: "rate must be positive",
+		},
+		{
+			name:    "invalid date",
+			file:    "rates.csv",
+			content: "date,from,to,rate\n15/03/2024,USD,IDR,15650.25\n",
+			wantErr: "invalid date",
+		},
+		{
+			name:    "short CSV row",
+			file:    "rates.csv",
+			content: "date,from,to,rate\n2024-03-15,USD,15650.25\n",
+			wantErr: "wrong number of fields",
+		},
+		{
+			name:    "malformed JSON",
+			file:    "rates.json",
+			content: `[{"date": "2024-03-15", "from": "USD"`,
+			wantErr: "failed to read FX rates",
+		},
+		{
+			name:    "duplicate date and pair",
+			file:    "rates.csv",
+			content: "date,from,to,rate\n2024-03-15,USD,IDR,15650.25\n2024-03-15,usd,IDR,15700.00\n",
+			wantErr: "rate 2: duplicate USD/IDR rate on 2024-03-15",
+		},
+		{
+			name:    "missing base currency",
+			file:    "rates.csv",
+			content: "date,from,to,rate\n2024-03-15,USD,,15650.25\n",
+			wantErr: "rate 1: missing currency",
+		},
+		{
+			name:    "missing foreign currency",
+			file:    "rates.json",
+			content: `[{"date": "2024-03-15", "to": "IDR", "rate": "15650.25"}]`,
+			wantErr: "rate 1: missing currency",
+		},
+		{
+			name:    "same currency on both sides",
+			file:    "rates.csv",
+			content: "date,from,to,rate\n2024-03-15,IDR,IDR,1\n",
+			wantErr: "rate from IDR to itself",
+		},
+		{
+			name:    "unsupported extension",
+			file:    "rates.txt",
+			content: "date,from,to,rate\n",
+			wantErr: "unsupported FX rate file",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			path := filepath.Join(t.TempDir(), tt.file)
+			if err := os.WriteFile(path, []byte(tt.content), 0o644); err != nil {
+				t.Fatalf("failed to write %s: %v", path, err)
+			}
+
+			table, err := LoadRateTable(path)
+			if tt.wantErr != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+					t.Fatalf("Expected error containing %q, got %v", tt.wantErr, err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("LoadRateTable failed: %v", err)
+			}
+
+			// The second rate applies from its own date onwards
+			converted, err := table.Convert(domain.NewMoney(10000, domain.CurrencyUSD), domain.CurrencyIDR, time.Date(2024, 3, 16, 0, 0, 0, 0, time.UTC))
+			if err != nil {
+				t.Fatalf("Convert failed: %v", err)
+			}
+			if converted.String() != "1565025.00" {
+				t.Errorf("Expected 100 USD = 1565025.00 IDR on 2024-03-16, got %s", converted)
+			}
+		})
+	}
+}
+
+func TestLoadRateTable_MissingFile(t *testing.T) {
+	if _, err := LoadRateTable(filepath.Join(t.TempDir(), "missing.csv")); err == nil {
+		t.Error("Expected error for a missing file")
+	}
+}
+
+func TestLoadRateTable_Fixture(t *testing.T) {
+	table, err := LoadRateTable(filepath.Join("..", "..", "..", "fixtures", "fx_rates.csv"))
+	if err != nil {
+		t.Fatalf("LoadRateTable failed: %v", err)
+	}
+
+	converted, err := table.Convert(domain.NewMoney(1000, domain.CurrencySGD), domain.CurrencyIDR, time.Date(2024, 3, 18, 0, 0, 0, 0, time.UTC))
+	if err != nil {
+		t.Fatalf("Convert failed: %v", err)
+	}
+	if converted.String() != "116207.50" {
+		t.Errorf("Expected 10 SGD = 116207.50 IDR on 2024-03-18, got %s", converted)
+	}
+}
@@ -0,0 +1,96 @@
+package input
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/farhaan/amartha-reconcile-system/internal/domain/statement"
+	"github.com/farhaan/amartha-reconcile-system/internal/infrastructure/csv"
+)
+
+// fixturesDir holds the sample files the README points users at
+var fixturesDir = filepath.Join("..", "..", "..", "fixtures")
+
+// TestFixtures reads every shipped sample file the way the CLI does, so a change to
+// detection or bank resolution cannot break the examples in the README unnoticed.
+func TestFixtures(t *testing.T) {
+	profiles, err := csv.LoadProfiles(filepath.Join(fixturesDir, "bank_profiles.json"))
+	if err != nil {
+		t.Fatalf("LoadProfiles failed: %v", err)
+	}
+
+	files := make([]string, 0)
+	for _, dir := range []string{fixturesDir, filepath.Join(fixturesDir, "layouts")} {
+		entries, err := os.ReadDir(dir)
+		if err != nil {
+			t.Fatalf("failed to list %s: %v", dir, err)
+		}
+		for _, entry := range entries {
+			if !entry.IsDir() {
+				files = append(files, filepath.Join(dir, entry.Name()))
+			}
+		}
+	}
+
+	registry := Default()
+	for _, path := range files {
+		name := filepath.Base(path)
+		switch {
+		case name == "bank_profiles.json", name == "fx_rates.csv", strings.HasSuffix(name, ".balances.json"):
+			continue // Not transaction files
+		}
+
+		rel, _ := filepath.Rel(fixturesDir, path)
+		t.Run(filepath.ToSlash(rel), func(t *testing.T) {
+			opts := Options{JobID: "job", FileID: "file", Sources: NewSourceResolver()}
+			if filepath.Base(filepath.Dir(path)) == "layouts" {
+				opts.Profiles = profiles
+			}
+
+			format, err := registry.Detect(path)
+			if err != nil {
+				t.Fatalf("Detect failed: %v", err)
+			}
+
+			if strings.Contains(name, "system_transactions") {
+				file, err := format.ReadSystem(path, opts)
+				if err != nil {
+					t.Fatalf("ReadSystem failed: %v", err)
+				}
+				if len(file.Transactions) == 0 {
+					t.Error("Expected system transactions")
+				}
+				return
+			}
+
+			statements, err := format.ReadBank(path, opts)
+			if strings.Contains(name, "ambiguous") {
+				// Documented as rejected: its bank cannot be determined
+				if !errors.Is(err, ErrUnknownSource) {
+					t.Errorf("Expected ErrUnknownSource, got %v", err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("ReadBank failed: %v", err)
+			}
+			if len(statements) == 0 {
+				t.Fatal("Expected at least one statement")
+			}
+			for _, stmt := range statements {
+				if len(stmt.Transactions) == 0 {
+					t.Errorf("Expected transactions in the %s statement", stmt.Statement.Source)
+				}
+				if len(stmt.Rejects) > 0 {
+					t.Errorf("Expected no rejected rows, got %d", len(stmt.Rejects))
+				}
+				if stmt.Statement.Check.Status == statement.BalanceMismatch {
+					t.Errorf("Expected the %s statement to balance: %s", stmt.Statement.Source, stmt.Statement.Check.Reason)
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,131 @@
+package input
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+
+	"github.com/farhaan/amartha-reconcile-system/internal/domain/statement"
+	"github.com/farhaan/amartha-reconcile-system/internal/domain/transaction"
+	"github.com/farhaan/amartha-reconcile-system/internal/infrastructure/csv"
+)
+
+// sniffLen is how much of a file formats get to look at when detecting it
+const sniffLen = 4096
+
+// ErrNotSupported is returned by formats that cannot hold the requested kind of file,
+// e.g. system transactions from a bank statement format
+var ErrNotSupported = errors.New("not supported by this format")
+
+// Format reads one kind of input file
+type Format interface {
+	// Name identifies the format in messages, e.g. "csv" or "mt940"
+	Name() string
+	// Detect reports whether a file starting with head is in this format
+	Detect(head []byte) bool
+	// ReadSystem reads a system transactions file
+	ReadSystem(filePath string, opts Options) (*SystemFile, error)
+	// ReadBank reads the statements of a bank file
+	ReadBank(filePath string, opts Options) ([]*BankStatement, error)
+}
+
+// Options are the per-run settings formats may use when reading a file
+type Options struct {
+	JobID    string
+	FileID   string
+	Sheet    string                        // Worksheet of spreadsheet inputs, "" for the first
+	Profiles map[string]*csv.ColumnProfile // Column layouts of bank exports, by bank
+	Sources  *SourceResolver               // Banks of statement files, nil for the filename convention only
+}
+
+// SystemFile is the result of reading a system transactions file
+type SystemFile struct {
+	Transactions []*transaction.Transaction
+	Rejects      []Reject // Rows that could not be read
+}
+
+// BankStatement is one statement of a bank file with all of its transactions, in or out of
+// the reconciliation period. Its balances are already checked against every transaction.
+type BankStatement struct {
+	Statement    *statement.Statement
+	Transactions []*transaction.Transaction
+	Rejects      []Reject // Rows that could not be read
+	Warnings     []string // Anything else that was left out or only partly read
+}
+
+// Registry picks the format of a file by looking at its content
+type Registry struct {
+	formats []Format
+}
+
+// NewRegistry creates an empty registry
+func NewRegistry() *Registry {
+	return &Registry{formats: make([]Format, 0)}
+}
+
+// Default returns a registry with the built-in formats: XLSX, camt.053/camt.054,
+// MT940 and CSV, which accepts any text file and so is tried last
+func Default() *Registry {
+	r := NewRegistry()
+	r.Register(csvFormat())
+	r.Register(mt940Format{})
+	r.Register(camtFormat{})
+	r.Register(xlsxFormat())
+	return r
+}
+
+// Register adds a format. Formats registered later are tried first, so a new
+// format can claim files that a more general one would also accept.
+func (r *Registry) Register(f Format) {
+	r.formats = append([]Format{f}, r.formats...)
+}
+
+// Lookup returns a registered format by name
+func (r *Registry) Lookup(name string) (Format, bool) {
+	for _, f := range r.formats {
+		if f.Name() == name {
+			return f, true
+		}
+	}
+	return nil, false
+}
+
+// Detect reads the start of a file and returns the first format that recognises it
+func (r *Registry) Detect(filePath string) (Format, error) {
+	file, err := os.Open(filePath)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open file %s: %w", filePath, err)
+	}
+	defer file.Close()
+
+	head := make([]byte, sniffLen)
+	n, err := io.ReadFull(file, head)
+	if err != nil && err != io.ErrUnexpectedEOF && err != io.EOF {
+		return nil, fmt.Errorf("failed to read file %s: %w", filePath, err)
+	}
+	head = head[:n]
+
+	for _, f := range r.formats {
+		if f.Detect(head) {
+			return f, nil
+		}
+	}
+
+	if bytes.HasPrefix(head, oleMagic) {
+		return nil, fmt.Errorf("%s: legacy .xls workbooks are not supported, save it as .xlsx", filePath)
+	}
+	return nil, fmt.Errorf("%s: unrecognised file format", filePath)
+}
+
+// Magic bytes of zip archives (XLSX workbooks) and OLE compound files (legacy XLS)
+var (
+	zipMagic = []byte("PK\x03\x04")
+	oleMagic = []byte("\xD0\xCF\x11\xE0\xA1\xB1\x1A\xE1")
+)
+
+// trimText drops a UTF-8 byte order mark and leading whitespace
+func trimText(head []byte) []byte {
+	return bytes.TrimLeft(bytes.TrimPrefix(head, []byte("\xEF\xBB\xBF")), " \t\r\n")
+}
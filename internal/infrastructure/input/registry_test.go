@@ -0,0 +1,165 @@
+package input
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestRegistry_Detect(t *testing.T) {
+	tests := []struct {
+		name    string
+		content string
+		want    string
+	}{
+		{"standard csv", "unique_identifier,amount,date\nBCA_TX_001,-150.50,2024-03-15\n", "csv"},
+		{"csv with bom and preamble", "\xEF\xBB\xBFAccount No: 0123\nRef No,Tanggal,Debet\n", "csv"},
+		{"xlsx", "PK\x03\x04\x14\x00\x06\x00", "xlsx"},
+		{"camt.053 namespace", `<?xml version="1.0"?><Document xmlns="urn:iso:std:iso:20022:tech:xsd:camt.053.001.02"><BkToCstmrStmt>`, "camt"},
+		{"camt.054 without namespace", "<Document>\n  <BkToCstmrDbtCdtNtfctn>", "camt"},
+		{"mt940 blocks", "{1:F01AMRTIDJAAXXX0000000000}{2:O9401200240322CENAIDJAAXXX00000000002403221200N}{4:\n:20:X\n", "mt940"},
+		{"bare mt940", "\r\n:20:STMT1\n:25:0123\n:60F:C240315IDR0,00\n", "mt940"},
+	}
+
+	registry := Default()
+	for _, tt := range tests {
+		path := writeTempFile(t, "statement.dat", tt.content)
+		format, err := registry.Detect(path)
+		if err != nil {
+			t.Errorf("%s: Detect failed: %v", tt.name, err)
+			continue
+		}
+		if format.Name() != tt.want {
+			t.Errorf("%s: expected %s, got %s", tt.name, tt.want, format.Name())
+		}
+	}
+}
+
+func TestRegistry_DetectUnknown(t *testing.T) {
+	tests := map[string]string{
+		"empty":     "",
+		"other xml": `<?xml version="1.0"?><Document xmlns="urn:iso:std:iso:20022:tech:xsd:pain.001.001.03"><CstmrCdtTrfInitn>`,
+		"xls":       "\xD0\xCF\x11\xE0\xA1\xB1\x1A\xE1\x00\x00",
+		"binary":    "\x00\x01,\x02",
+	}
+
+	registry := Default()
+	for name, content := range tests {
+		if format, err := registry.Detect(writeTempFile(t, "statement.csv", content)); err == nil {
+			t.Errorf("%s: expected error, got format %s", name, format.Name())
+		}
+	}
+}
+
+// testFormat accepts files starting with "TEST"
+type testFormat struct{}
+
+func (testFormat) Name() string            { return "test" }
+func (testFormat) Detect(head []byte) bool { return len(head) >= 4 && string(head[:4]) == "TEST" }
+func (testFormat) ReadSystem(string, Options) (*SystemFile, error) {
+	return nil, ErrNotSupported
+}
+func (testFormat) ReadBank(string, Options) ([]*BankStatement, error) {
+	return nil, ErrNotSupported
+}
+
+func TestRegistry_Register(t *testing.T) {
+	registry := Default()
+	path := writeTempFile(t, "statement.csv", "TEST,amount\n")
+
+	if format, _ := registry.Detect(path); format.Name() != "csv" {
+		t.Fatalf("Expected csv before registering, got %s", format.Name())
+	}
+
+	registry.Register(testFormat{})
+	format, err := registry.Detect(path)
+	if err != nil {
+		t.Fatalf("Detect failed: %v", err)
+	}
+	if format.Name() != "test" {
+		t.Errorf("Expected the registered format to take precedence, got %s", format.Name())
+	}
+	if _, ok := registry.Lookup("mt940"); !ok {
+		t.Error("Expected built-in formats to stay registered")
+	}
+}
+
+func TestFormat_ReadBank(t *testing.T) {
+	registry := Default()
+	csvPath := writeTempFile(t, "bca_statement_2024-03-15.csv",
+		"unique_identifier,amount,date\nBCA_TX_001,-150.50,2024-03-15\nBCA_TX_002,oops,2024-03-16\n")
+	mt940Path := writeTempFile(t, "mandiri_statement_2024-03-15.csv",
+		":20:A\n:60F:C240315IDR0,\n:61:240315C5,NTRFREF1\n:62F:C240315IDR5,\n")
+
+	tests := []struct {
+		path    string
+		source  string
+		txns    int
+		rejects int
+	}{
+		{csvPath, "BCA", 1, 1},
+		{mt940Path, "MANDIRI", 1, 0},
+	}
+
+	for _, tt := range tests {
+		format, err := registry.Detect(tt.path)
+		if err != nil {
+			t.Fatalf("Detect failed: %v", err)
+		}
+		statements, err := format.ReadBank(tt.path, Options{JobID: "job", FileID: "file"})
+		if err != nil {
+			t.Fatalf("%s: ReadBank failed: %v", format.Name(), err)
+		}
+		if len(statements) != 1 {
+			t.Fatalf("%s: expected 1 statement, got %d", format.Name(), len(statements))
+		}
+
+		stmt := statements[0]
+		if stmt.Statement.Source != tt.source {
+			t.Errorf("%s: expected source %s, got %s", format.Name(), tt.source, stmt.Statement.Source)
+		}
+		if len(stmt.Transactions) != tt.txns {
+			t.Errorf("%s: expected %d transactions, got %d", format.Name(), tt.txns, len(stmt.Transactions))
+		}
+		if len(stmt.Rejects) != tt.rejects {
+			t.Errorf("%s: expected %d rejects, got %+v", format.Name(), tt.rejects, stmt.Rejects)
+		}
+	}
+
+	format, _ := registry.Lookup("mt940")
+	if _, err := format.ReadSystem(mt940Path, Options{}); !errors.Is(err, ErrNotSupported) {
+		t.Errorf("Expected ErrNotSupported for MT940 system files, got %v", err)
+	}
+}
+
+func TestFormat_ReadBank_CamtWithoutServicer(t *testing.T) {
+	path := writeTempFile(t, "export.xml", `<Document><BkToCstmrStmt><Stmt><Id>1</Id>`+
+		`<Ntry><Amt Ccy="IDR">5.00</Amt><CdtDbtInd>CRDT</CdtDbtInd><Sts>BOOK</Sts><BookgDt><Dt>2024-03-15</Dt></BookgDt></Ntry>`+
+		`</Stmt></BkToCstmrStmt></Document>`)
+	format, _ := Default().Lookup("camt")
+
+	if _, err := format.ReadBank(path, Options{JobID: "job", FileID: "file", Sources: NewSourceResolver()}); !errors.Is(err, ErrUnknownSource) {
+		t.Errorf("Expected ErrUnknownSource without a bank for the file, got %v", err)
+	}
+
+	sources := NewSourceResolver()
+	sources.SetFile(path, "BNI")
+	statements, err := format.ReadBank(path, Options{JobID: "job", FileID: "file", Sources: sources})
+	if err != nil {
+		t.Fatalf("ReadBank failed: %v", err)
+	}
+	if statements[0].Statement.Source != "BNI" || len(statements[0].Transactions) != 1 {
+		t.Errorf("Expected 1 BNI transaction, got %d from %s", len(statements[0].Transactions), statements[0].Statement.Source)
+	}
+}
+
+func writeTempFile(t *testing.T, name, content string) string {
+	t.Helper()
+
+	path := filepath.Join(t.TempDir(), name)
+	if err := os.WriteFile(path, []byte(content), 0o644); err != nil {
+		t.Fatalf("failed to write %s: %v", path, err)
+	}
+	return path
+}
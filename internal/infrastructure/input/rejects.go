@@ -0,0 +1,106 @@
+package input
+
+import (
+	"bytes"
+	encodingcsv "encoding/csv"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+
+	"github.com/farhaan/amartha-reconcile-system/internal/infrastructure/csv"
+)
+
+// Reject is a row of an input file that was left out, and why
+type Reject struct {
+	File     string            `json:"file"`
+	Row      int64             `json:"row"` // 1-based data row, 0 when unknown
+	Category csv.ErrorCategory `json:"category"`
+	Reason   string            `json:"reason"`
+	Record   []string          `json:"record"` // Raw fields, nil when the row could not be split into fields
+}
+
+// newReject describes a row error of a file
+func newReject(filePath string, err error) Reject {
+	reject := Reject{File: filePath, Category: csv.CategoryOther, Reason: err.Error()}
+
+	var rowErr *csv.RowError
+	if errors.As(err, &rowErr) {
+		reject.Row = rowErr.Row
+		reject.Record = rowErr.Record
+		reject.Category = rowErr.Category
+		reject.Reason = rowErr.Err.Error()
+	}
+	return reject
+}
+
+// WriteRejects writes rejected rows to a file, as JSON when its extension is .json and CSV otherwise
+func WriteRejects(filePath string, rejects []Reject) error {
+	file, err := os.Create(filePath)
+	if err != nil {
+		return fmt.Errorf("failed to create file %s: %w", filePath, err)
+	}
+	defer file.Close()
+
+	if strings.EqualFold(filepath.Ext(filePath), ".json") {
+		err = WriteRejectsJSON(file, rejects)
+	} else {
+		err = WriteRejectsCSV(file, rejects)
+	}
+	if err != nil {
+		return fmt.Errorf("failed to write %s: %w", filePath, err)
+	}
+	return file.Close()
+}
+
+// WriteRejectsJSON writes rejected rows as a JSON array
+func WriteRejectsJSON(w io.Writer, rejects []Reject) error {
+	if rejects == nil {
+		rejects = []Reject{}
+	}
+	encoder := json.NewEncoder(w)
+	encoder.SetIndent("", "  ")
+	return encoder.Encode(rejects)
+}
+
+// WriteRejectsCSV writes rejected rows as CSV. The raw record is kept as one
+// CSV-encoded column, since rejected rows do not all have the same width.
+func WriteRejectsCSV(w io.Writer, rejects []Reject) error {
+	writer := encodingcsv.NewWriter(w)
+	if err := writer.Write([]string{"file", "row", "category", "reason", "record"}); err != nil {
+		return err
+	}
+
+	for _, reject := range rejects {
+		record, err := encodeRecord(reject.Record)
+		if err != nil {
+			return err
+		}
+		row := []string{reject.File, strconv.FormatInt(reject.Row, 10), string(reject.Category), reject.Reason, record}
+		if err := writer.Write(row); err != nil {
+			return err
+		}
+	}
+
+	writer.Flush()
+	return writer.Error()
+}
+
+// encodeRecord joins raw fields back into one CSV line
+func encodeRecord(record []string) (string, error) {
+	if record == nil {
+		return "", nil
+	}
+
+	var buf bytes.Buffer
+	writer := encodingcsv.NewWriter(&buf)
+	if err := writer.Write(record); err != nil {
+		return "", err
+	}
+	writer.Flush()
+	return strings.TrimRight(buf.String(), "\n"), writer.Error()
+}
@@ -0,0 +1,108 @@
+package input
+
+import (
+	"bytes"
+	"encoding/json"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/farhaan/amartha-reconcile-system/internal/infrastructure/csv"
+)
+
+func TestReadSystem_Rejects(t *testing.T) {
+	path := writeTempFile(t, "system.csv", "trxID,amount,source,type,transactionTime\n"+
+		"TRX001,150.50,BCA,DEBIT,2024-03-15T10:30:00Z\n"+
+		"TRX002,1.000,BCA,DEBIT,2024-03-15T10:30:00Z\n"+
+		"TRX003,150.50,BCA,REFUND,2024-03-15T10:30:00Z\n"+
+		"TRX004,150.50,BCA,DEBIT,15/03/2024\n"+
+		"TRX005,150.50\n"+
+		"TRX006,150.50,BCA,DEBIT,2024-03-15T10:30:00Z,XYZ\n")
+
+	format, _ := Default().Lookup("csv")
+	file, err := format.ReadSystem(path, Options{})
+	if err != nil {
+		t.Fatalf("ReadSystem failed: %v", err)
+	}
+	if len(file.Transactions) != 2 {
+		t.Errorf("Expected 2 transactions, got %d", len(file.Transactions))
+	}
+
+	want := []struct {
+		row      int64
+		category csv.ErrorCategory
+	}{
+		{3, csv.CategoryBadType},
+		{4, csv.CategoryBadDate},
+		{5, csv.CategoryColumnCount},
+		{6, csv.CategoryColumnCount},
+	}
+	if len(file.Rejects) != len(want) {
+		t.Fatalf("Expected %d rejects, got %+v", len(want), file.Rejects)
+	}
+	for i, w := range want {
+		reject := file.Rejects[i]
+		if reject.Row != w.row || reject.Category != w.category {
+			t.Errorf("reject %d: expected row %d %s, got row %d %s", i+1, w.row, w.category, reject.Row, reject.Category)
+		}
+		if reject.File != path || len(reject.Record) == 0 || reject.Reason == "" {
+			t.Errorf("reject %d: expected file, record and reason, got %+v", i+1, reject)
+		}
+	}
+	if !reflect.DeepEqual(file.Rejects[2].Record, []string{"TRX005", "150.50"}) {
+		t.Errorf("Expected the raw record of the short row, got %q", file.Rejects[2].Record)
+	}
+}
+
+func TestReadSystem_RejectsCurrency(t *testing.T) {
+	path := writeTempFile(t, "system.csv", "trxID,amount,source,type,transactionTime,currency\n"+
+		"TRX001,abc,BCA,DEBIT,2024-03-15T10:30:00Z,IDR\n"+
+		"TRX002,150.50,BCA,DEBIT,2024-03-15T10:30:00Z,XYZ\n")
+
+	format, _ := Default().Lookup("csv")
+	file, err := format.ReadSystem(path, Options{})
+	if err != nil {
+		t.Fatalf("ReadSystem failed: %v", err)
+	}
+	if len(file.Rejects) != 2 {
+		t.Fatalf("Expected 2 rejects, got %+v", file.Rejects)
+	}
+	if file.Rejects[0].Category != csv.CategoryBadAmount || file.Rejects[1].Category != csv.CategoryBadCurrency {
+		t.Errorf("Expected bad_amount and bad_currency, got %s and %s", file.Rejects[0].Category, file.Rejects[1].Category)
+	}
+}
+
+func TestWriteRejects(t *testing.T) {
+	rejects := []Reject{
+		{File: "a.csv", Row: 2, Category: csv.CategoryBadAmount, Reason: `invalid amount "x"`, Record: []string{"TRX1", "x", "note, with comma"}},
+		{File: "a.csv", Row: 3, Category: csv.CategoryUnreadable, Reason: "bare quote"},
+	}
+
+	var buf bytes.Buffer
+	if err := WriteRejectsCSV(&buf, rejects); err != nil {
+		t.Fatalf("WriteRejectsCSV failed: %v", err)
+	}
+	want := "file,row,category,reason,record\n" +
+		`a.csv,2,bad_amount,"invalid amount ""x""","TRX1,x,""note, with comma"""` + "\n" +
+		"a.csv,3,unreadable,bare quote,\n"
+	if buf.String() != want {
+		t.Errorf("Unexpected CSV:\n%s\nwant:\n%s", buf.String(), want)
+	}
+
+	buf.Reset()
+	if err := WriteRejectsJSON(&buf, rejects); err != nil {
+		t.Fatalf("WriteRejectsJSON failed: %v", err)
+	}
+	var decoded []Reject
+	if err := json.Unmarshal(buf.Bytes(), &decoded); err != nil {
+		t.Fatalf("invalid JSON: %v", err)
+	}
+	if !reflect.DeepEqual(decoded, rejects) {
+		t.Errorf("Expected %+v, got %+v", rejects, decoded)
+	}
+
+	buf.Reset()
+	if err := WriteRejectsJSON(&buf, nil); err != nil || strings.TrimSpace(buf.String()) != "[]" {
+		t.Errorf("Expected an empty array for no rejects, got %q (%v)", buf.String(), err)
+	}
+}
@@ -0,0 +1,158 @@
+package input
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+
+	"github.com/farhaan/amartha-reconcile-system/internal/domain"
+)
+
+// ErrUnknownSource is returned when the bank of a statement file cannot be determined
+var ErrUnknownSource = errors.New("bank of the file cannot be determined")
+
+// conventionPattern is the {bank}_statement_{date} filename convention
+var conventionPattern = regexp.MustCompile(`^([A-Za-z0-9]+)_statement_`)
+
+// SourceResolver works out which bank a statement file belongs to. In order of precedence:
+// files named explicitly (with SetFile or a manifest), filename patterns, and finally the
+// {bank}_statement_{date} convention, which is only trusted for known banks.
+type SourceResolver struct {
+	files    map[string]string // Cleaned path to bank
+	patterns []*regexp.Regexp
+	known    map[string]bool // Banks accepted from the filename convention besides the BIC list
+}
+
+// Manifest assigns statement files to banks, e.g.
+// {"files": {"exports/bca-march.csv": "BCA"}, "patterns": ["^(?P<bank>[a-z]+)-"]}.
+// File paths are relative to the manifest.
+type Manifest struct {
+	Files    map[string]string `json:"files"`
+	Patterns []string          `json:"patterns"`
+}
+
+// NewSourceResolver creates a resolver that only knows the filename convention
+func NewSourceResolver() *SourceResolver {
+	return &SourceResolver{
+		files:    make(map[string]string),
+		patterns: make([]*regexp.Regexp, 0),
+		known:    make(map[string]bool),
+	}
+}
+
+// SetFile assigns a file to a bank
+func (r *SourceResolver) SetFile(filePath, bank string) {
+	bank = normalizeBank(bank)
+	r.files[filepath.Clean(filePath)] = bank
+	r.known[bank] = true
+}
+
+// AddPattern adds a regular expression matched against file names. The bank is its
+// "bank" group, or its first group when it has no group of that name.
+func (r *SourceResolver) AddPattern(pattern string) error {
+	re, err := regexp.Compile(pattern)
+	if err != nil {
+		return fmt.Errorf("invalid bank pattern %q: %w", pattern, err)
+	}
+	if re.NumSubexp() == 0 {
+		return fmt.Errorf("bank pattern %q has no group capturing the bank", pattern)
+	}
+	r.patterns = append(r.patterns, re)
+	return nil
+}
+
+// AddKnownBank lets the filename convention accept a bank that is not in the BIC list
+func (r *SourceResolver) AddKnownBank(bank string) {
+	r.known[normalizeBank(bank)] = true
+}
+
+// LoadManifest reads a manifest file and returns the statement files it lists
+func (r *SourceResolver) LoadManifest(filePath string) ([]string, error) {
+	data, err := os.ReadFile(filePath)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open file %s: %w", filePath, err)
+	}
+
+	var manifest Manifest
+	if err := json.Unmarshal(data, &manifest); err != nil {
+		return nil, fmt.Errorf("failed to parse bank manifest %s: %w", filePath, err)
+	}
+
+	dir := filepath.Dir(filePath)
+	files := make([]string, 0, len(manifest.Files))
+	for path, bank := range manifest.Files {
+		if strings.TrimSpace(bank) == "" {
+			return nil, fmt.Errorf("bank manifest %s: no bank for %s", filePath, path)
+		}
+		if !filepath.IsAbs(path) {
+			path = filepath.Join(dir, path)
+		}
+		r.SetFile(path, bank)
+		files = append(files, path)
+	}
+
+	for _, pattern := range manifest.Patterns {
+		if err := r.AddPattern(pattern); err != nil {
+			return nil, fmt.Errorf("bank manifest %s: %w", filePath, err)
+		}
+	}
+
+	return files, nil
+}
+
+// Explicit returns the bank a file was assigned to with SetFile or a manifest
+func (r *SourceResolver) Explicit(filePath string) (string, bool) {
+	if r == nil {
+		return "", false
+	}
+	bank, ok := r.files[filepath.Clean(filePath)]
+	return bank, ok
+}
+
+// Resolve returns the bank of a statement file, or an error wrapping ErrUnknownSource
+func (r *SourceResolver) Resolve(filePath string) (string, error) {
+	if bank, ok := r.Explicit(filePath); ok {
+		return bank, nil
+	}
+
+	filename := filepath.Base(filePath)
+	if r != nil {
+		for _, re := range r.patterns {
+			if bank := patternBank(re, filename); bank != "" {
+				return bank, nil
+			}
+		}
+	}
+
+	if match := conventionPattern.FindStringSubmatch(filename); match != nil {
+		bank := normalizeBank(match[1])
+		if domain.IsKnownBank(bank) || (r != nil && r.known[bank]) {
+			return bank, nil
+		}
+		return "", fmt.Errorf("%w: %s names bank %s, which is not a known bank", ErrUnknownSource, filename, bank)
+	}
+
+	return "", fmt.Errorf("%w: %s", ErrUnknownSource, filename)
+}
+
+// patternBank returns the bank a pattern captures from a filename, or "" when it does not match
+func patternBank(re *regexp.Regexp, filename string) string {
+	match := re.FindStringSubmatch(filename)
+	if match == nil {
+		return ""
+	}
+
+	group := 1
+	if i := re.SubexpIndex("bank"); i > 0 {
+		group = i
+	}
+	return normalizeBank(match[group])
+}
+
+func normalizeBank(bank string) string {
+	return strings.ToUpper(strings.TrimSpace(bank))
+}
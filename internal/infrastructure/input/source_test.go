@@ -0,0 +1,92 @@
+package input
+
+import (
+	"errors"
+	"path/filepath"
+	"testing"
+)
+
+func TestSourceResolver_Resolve(t *testing.T) {
+	resolver := NewSourceResolver()
+	resolver.SetFile("exports/../exports/march.csv", "bca")
+	resolver.AddKnownBank("bsi")
+	if err := resolver.AddPattern(`^(?P<bank>[a-z]+)-mutasi`); err != nil {
+		t.Fatalf("AddPattern failed: %v", err)
+	}
+
+	tests := []struct {
+		path string
+		want string
+	}{
+		{"exports/march.csv", "BCA"},
+		{"/data/mandiri-mutasi-march.csv", "MANDIRI"},
+		{"fixtures/bni_statement_2024-03-15.csv", "BNI"},
+		{"bsi_statement_2024-03-15.csv", "BSI"},
+	}
+
+	for _, tt := range tests {
+		got, err := resolver.Resolve(tt.path)
+		if err != nil {
+			t.Errorf("%s: Resolve failed: %v", tt.path, err)
+			continue
+		}
+		if got != tt.want {
+			t.Errorf("%s: expected %s, got %s", tt.path, tt.want, got)
+		}
+	}
+}
+
+func TestSourceResolver_Unknown(t *testing.T) {
+	paths := []string{
+		"combined_statement_ambiguous_2024-03-15.csv",
+		"bca-march.csv",
+	}
+
+	for _, resolver := range []*SourceResolver{nil, NewSourceResolver()} {
+		for _, path := range paths {
+			if bank, err := resolver.Resolve(path); !errors.Is(err, ErrUnknownSource) {
+				t.Errorf("%s: expected ErrUnknownSource, got %q (%v)", path, bank, err)
+			}
+		}
+	}
+}
+
+func TestSourceResolver_AddPattern(t *testing.T) {
+	resolver := NewSourceResolver()
+	if err := resolver.AddPattern(`^bca`); err == nil {
+		t.Error("Expected error for a pattern without a group")
+	}
+	if err := resolver.AddPattern(`^(bca`); err == nil {
+		t.Error("Expected error for an invalid pattern")
+	}
+}
+
+func TestSourceResolver_LoadManifest(t *testing.T) {
+	path := writeTempFile(t, "manifest.json",
+		`{"files": {"bca-march.csv": "BCA", "/abs/mutasi.csv": "bri"}, "patterns": ["^(dbs)-"]}`)
+	dir := filepath.Dir(path)
+
+	resolver := NewSourceResolver()
+	files, err := resolver.LoadManifest(path)
+	if err != nil {
+		t.Fatalf("LoadManifest failed: %v", err)
+	}
+	if len(files) != 2 {
+		t.Errorf("Expected 2 files, got %v", files)
+	}
+
+	tests := map[string]string{
+		filepath.Join(dir, "bca-march.csv"): "BCA",
+		"/abs/mutasi.csv":                   "BRI",
+		"dbs-march.csv":                     "DBS",
+	}
+	for file, want := range tests {
+		if got, err := resolver.Resolve(file); err != nil || got != want {
+			t.Errorf("%s: expected %s, got %s (%v)", file, want, got, err)
+		}
+	}
+
+	if _, err := NewSourceResolver().LoadManifest(writeTempFile(t, "bad.json", `{"files": {"x.csv": ""}}`)); err == nil {
+		t.Error("Expected error for a file without bank")
+	}
+}
@@ -0,0 +1,130 @@
+package input
+
+import (
+	"bytes"
+	"encoding/xml"
+	"fmt"
+	"regexp"
+	"strings"
+
+	"github.com/farhaan/amartha-reconcile-system/internal/infrastructure/camt"
+	"github.com/farhaan/amartha-reconcile-system/internal/infrastructure/mt940"
+)
+
+// camtFormat reads ISO 20022 camt.053 statements and camt.054 notifications
+type camtFormat struct{}
+
+func (camtFormat) Name() string {
+	return "camt"
+}
+
+// Detect looks for a camt namespace on the Document root, or a statement or
+// notification element as the first child
+func (camtFormat) Detect(head []byte) bool {
+	decoder := xml.NewDecoder(bytes.NewReader(head))
+	for depth := 0; depth < 2; {
+		token, err := decoder.Token()
+		if err != nil {
+			return false
+		}
+		start, ok := token.(xml.StartElement)
+		if !ok {
+			continue
+		}
+		switch {
+		case strings.Contains(start.Name.Space, ":camt.053.") || strings.Contains(start.Name.Space, ":camt.054."):
+			return true
+		case start.Name.Local == "BkToCstmrStmt" || start.Name.Local == "BkToCstmrDbtCdtNtfctn":
+			return true
+		}
+		depth++
+	}
+	return false
+}
+
+func (camtFormat) ReadSystem(filePath string, opts Options) (*SystemFile, error) {
+	return nil, fmt.Errorf("camt: %w", ErrNotSupported)
+}
+
+// ReadBank reads a camt file. Statements that do not identify the account
+// servicer take the bank the resolver finds for the file.
+func (camtFormat) ReadBank(filePath string, opts Options) ([]*BankStatement, error) {
+	source, sourceErr := opts.Sources.Resolve(filePath)
+	accountStatements, err := camt.ParseFile(filePath, source, opts.JobID, opts.FileID)
+	if err != nil {
+		if sourceErr != nil {
+			return nil, fmt.Errorf("%w (%w)", err, sourceErr)
+		}
+		return nil, err
+	}
+
+	bank, explicit := opts.Sources.Explicit(filePath)
+	results := make([]*BankStatement, 0, len(accountStatements))
+	for _, accountStatement := range accountStatements {
+		result := &BankStatement{Statement: accountStatement.Statement, Transactions: accountStatement.Transactions}
+		if explicit {
+			result.setSource(bank)
+		}
+		if accountStatement.Skipped > 0 {
+			result.Warnings = append(result.Warnings, fmt.Sprintf("Skipped %d entries that are not booked", accountStatement.Skipped))
+		}
+
+		result.Statement.VerifyBalances(amountsOf(result.Transactions))
+		results = append(results, result)
+	}
+	return results, nil
+}
+
+// mt940Format reads SWIFT MT940 statements
+type mt940Format struct{}
+
+// mt940Start matches the start of an MT940 file: SWIFT header blocks of a 940
+// message, or a bare message starting with its :20: reference
+var mt940Start = regexp.MustCompile(`^(\{1:[^}]*\}\{2:[IO]940|:20:)`)
+
+func (mt940Format) Name() string {
+	return "mt940"
+}
+
+func (mt940Format) Detect(head []byte) bool {
+	return mt940Start.Match(trimText(head))
+}
+
+func (mt940Format) ReadSystem(filePath string, opts Options) (*SystemFile, error) {
+	return nil, fmt.Errorf("mt940: %w", ErrNotSupported)
+}
+
+// ReadBank reads an MT940 file. Messages without a sender BIC in their header
+// take the bank the resolver finds for the file.
+func (mt940Format) ReadBank(filePath string, opts Options) ([]*BankStatement, error) {
+	source, sourceErr := opts.Sources.Resolve(filePath)
+	accountStatements, err := mt940.ParseFile(filePath, source, opts.JobID, opts.FileID)
+	if err != nil {
+		if sourceErr != nil {
+			return nil, fmt.Errorf("%w (%w)", err, sourceErr)
+		}
+		return nil, err
+	}
+
+	bank, explicit := opts.Sources.Explicit(filePath)
+	results := make([]*BankStatement, 0, len(accountStatements))
+	for _, accountStatement := range accountStatements {
+		result := &BankStatement{Statement: accountStatement.Statement, Transactions: accountStatement.Transactions}
+		if explicit {
+			result.setSource(bank)
+		}
+		result.Statement.VerifyBalances(amountsOf(result.Transactions))
+		results = append(results, result)
+	}
+	return results, nil
+}
+
+// setSource assigns the statement and its transactions to a bank named explicitly
+// for the file, overriding the bank found in the file itself
+func (b *BankStatement) setSource(bank string) {
+	b.Statement.Source = bank
+	for _, txn := range b.Transactions {
+		txn.Source = bank
+		txn.RawData["bankSource"] = bank
+	}
+}
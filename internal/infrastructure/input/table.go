@@ -0,0 +1,200 @@
+package input
+
+import (
+	"bytes"
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/farhaan/amartha-reconcile-system/internal/domain"
+	"github.com/farhaan/amartha-reconcile-system/internal/domain/statement"
+	"github.com/farhaan/amartha-reconcile-system/internal/domain/transaction"
+	"github.com/farhaan/amartha-reconcile-system/internal/infrastructure/csv"
+	"github.com/farhaan/amartha-reconcile-system/internal/infrastructure/xlsx"
+)
+
+// tableFormat reads tabular files: CSV files and XLSX sheets share the same
+// columns, bank layouts and statement checks
+type tableFormat struct {
+	name   string
+	detect func(head []byte) bool
+	open   func(filePath, sheet string) (*csv.Reader, error)
+}
+
+func csvFormat() *tableFormat {
+	return &tableFormat{
+		name:   "csv",
+		detect: isDelimitedText,
+		open: func(filePath, _ string) (*csv.Reader, error) {
+			return csv.NewReader(filePath)
+		},
+	}
+}
+
+func xlsxFormat() *tableFormat {
+	return &tableFormat{
+		name:   "xlsx",
+		detect: func(head []byte) bool { return bytes.HasPrefix(head, zipMagic) },
+		open: func(filePath, sheet string) (*csv.Reader, error) {
+			sheetReader, err := xlsx.Open(filePath, sheet)
+			if err != nil {
+				return nil, err
+			}
+			return csv.NewRecordReader(filePath, sheetReader)
+		},
+	}
+}
+
+// isDelimitedText reports whether head looks like comma separated text: no binary
+// bytes, and a comma within the first lines
+func isDelimitedText(head []byte) bool {
+	if len(head) == 0 || bytes.IndexByte(head, 0) >= 0 {
+		return false
+	}
+	return bytes.IndexByte(head, ',') >= 0
+}
+
+func (f *tableFormat) Name() string {
+	return f.name
+}
+
+func (f *tableFormat) Detect(head []byte) bool {
+	return f.detect(head)
+}
+
+func (f *tableFormat) ReadSystem(filePath string, opts Options) (*SystemFile, error) {
+	reader, err := f.open(filePath, opts.Sheet)
+	if err != nil {
+		return nil, err
+	}
+
+	result := &SystemFile{Transactions: make([]*transaction.Transaction, 0)}
+	err = reader.ReadSystemTransactions(func(row *csv.SystemTransactionRow, rowErr error) error {
+		if rowErr != nil {
+			result.Rejects = append(result.Rejects, newReject(filePath, rowErr))
+			return nil // Continue processing
+		}
+
+		txn, err := csv.ParseSystemTransaction(row, opts.JobID, opts.FileID)
+		if err != nil {
+			result.Rejects = append(result.Rejects, newReject(filePath, err))
+			return nil // Continue processing
+		}
+
+		result.Transactions = append(result.Transactions, txn)
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return result, nil
+}
+
+func (f *tableFormat) ReadBank(filePath string, opts Options) ([]*BankStatement, error) {
+	// Files that combine several banks can do without a bank of their own, as long as every row has a source
+	bankSource, sourceErr := opts.Sources.Resolve(filePath)
+
+	sheet := opts.Sheet
+	profile, hasProfile := opts.Profiles[bankSource]
+	if hasProfile && profile.Sheet != "" {
+		sheet = profile.Sheet
+	}
+
+	reader, err := f.open(filePath, sheet)
+	if err != nil {
+		return nil, err
+	}
+
+	txns := make([]*transaction.Transaction, 0)
+	lines := make([]statement.BalanceLine, 0)
+	rejects := make([]Reject, 0)
+	balanceErrorCount := 0
+
+	handleRow := func(row *csv.BankStatementRow, rowErr error) error {
+		if rowErr != nil {
+			rejects = append(rejects, newReject(filePath, rowErr))
+			return nil // Continue processing
+		}
+		if row.Source == "" && sourceErr != nil {
+			return sourceErr
+		}
+
+		txn, err := csv.ParseBankTransaction(row, opts.JobID, opts.FileID, bankSource)
+		if err != nil {
+			rejects = append(rejects, newReject(filePath, err))
+			return nil // Continue processing
+		}
+
+		// A bad balance cell only weakens the running balance check, the transaction is still matched
+		balance, err := csv.ParseBalance(row, txn.Amount.Currency)
+		if err != nil {
+			balanceErrorCount++
+		}
+		lines = append(lines, statement.BalanceLine{RowNumber: row.RowNumber, ID: txn.ID, Amount: txn.Amount, Balance: balance})
+
+		txns = append(txns, txn)
+		return nil
+	}
+
+	// Banks with a column profile use their own layout, everyone else the standard one
+	if hasProfile {
+		err = reader.ReadBankStatementsWithProfile(profile, handleRow)
+	} else {
+		err = reader.ReadBankStatements(handleRow)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	if bankSource == "" {
+		bankSource = joinSources(txns)
+	}
+
+	// Account number and balances from the lines around the transaction table
+	metadata := reader.Metadata()
+	if hasProfile {
+		profile.NormalizeBalances(metadata)
+	}
+	stmt, err := csv.ParseStatement(metadata, filePath, bankSource, domain.DefaultCurrency)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", filePath, err)
+	}
+	if _, err := csv.ApplyBalanceSidecar(stmt); err != nil {
+		return nil, err
+	}
+
+	result := &BankStatement{Statement: stmt, Transactions: txns, Rejects: rejects}
+	if balanceErrorCount > 0 {
+		result.Warnings = append(result.Warnings, fmt.Sprintf("Ignored %d unreadable running balances", balanceErrorCount))
+	}
+
+	check := stmt.VerifyBalances(amountsOf(txns))
+	if check.Status == statement.BalanceMismatch && len(rejects) > 0 {
+		stmt.Check.Reason += fmt.Sprintf(" (%d rows could not be read)", len(rejects))
+	}
+	stmt.VerifyRunningBalance(lines)
+
+	return []*BankStatement{result}, nil
+}
+
+// joinSources names a statement that combines several banks, e.g. "BCA+MANDIRI"
+func joinSources(txns []*transaction.Transaction) string {
+	sources := make([]string, 0)
+	for _, txn := range txns {
+		if !slices.Contains(sources, txn.Source) {
+			sources = append(sources, txn.Source)
+		}
+	}
+	slices.Sort(sources)
+	return strings.Join(sources, "+")
+}
+
+// amountsOf returns the amounts of transactions, for balance checks
+func amountsOf(txns []*transaction.Transaction) []domain.Money {
+	amounts := make([]domain.Money, len(txns))
+	for i, txn := range txns {
+		amounts[i] = txn.Amount
+	}
+	return amounts
+}
@@ -0,0 +1,337 @@
+package mt940
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"os"
+	"regexp"
+	"strings"
+	"time"
+
+	"github.com/farhaan/amartha-reconcile-system/internal/domain"
+	"github.com/farhaan/amartha-reconcile-system/internal/domain/statement"
+	"github.com/farhaan/amartha-reconcile-system/internal/domain/transaction"
+)
+
+// AccountStatement is one MT940 message: the account details and balances,
+// and its :61: statement lines as bank transactions
+type AccountStatement struct {
+	Statement    *statement.Statement
+	Transactions []*transaction.Transaction
+}
+
+// tagPattern matches the start of a field, e.g. ":61:" or ":60F:"
+var tagPattern = regexp.MustCompile(`^:(\d{2}[A-Z]?):`)
+
+// statementLinePattern splits a :61: field. Groups: value date, entry date, debit/credit mark,
+// funds code, amount, transaction type, customer reference, bank reference, supplementary details.
+var statementLinePattern = regexp.MustCompile(
+	`^(\d{6})(\d{4})?(RC|RD|C|D)([A-Z])?(\d+,\d*)([NFS][A-Z0-9]{3})([^\n]*?)(?://([^\n]*))?(?:\n((?s:.*)))?$`)
+
+// balancePattern splits a balance field such as :60F:C240315IDR20000,00
+var balancePattern = regexp.MustCompile(`^(C|D)(\d{6})([A-Z]{3})(\d+,\d*)$`)
+
+// ParseFile reads an MT940 file. source names the bank for messages whose
+// header does not identify the sending bank.
+func ParseFile(filePath, source, jobID, fileID string) ([]*AccountStatement, error) {
+	file, err := os.Open(filePath)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open file %s: %w", filePath, err)
+	}
+	defer file.Close()
+
+	statements, err := Parse(file, filePath, source, jobID, fileID)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", filePath, err)
+	}
+	return statements, nil
+}
+
+// Parse converts the messages of an MT940 file into statements. Messages may be wrapped in
+// SWIFT blocks ({1:...}{2:...}{4: ... -}) or follow each other bare, each starting at :20:.
+// Every :61: line becomes one bank transaction with its :86: narrative, dated by its entry
+// date (value date when there is none). The references are kept in RawData.
+func Parse(r io.Reader, filePath, source, jobID, fileID string) ([]*AccountStatement, error) {
+	messages, err := splitMessages(r)
+	if err != nil {
+		return nil, err
+	}
+	if len(messages) == 0 {
+		return nil, fmt.Errorf("no MT940 message found")
+	}
+
+	statements := make([]*AccountStatement, 0, len(messages))
+	for i, msg := range messages {
+		stmt, err := parseMessage(msg, filePath, source, jobID, fileID)
+		if err != nil {
+			return nil, fmt.Errorf("message %d (%s): %w", i+1, msg.reference(), err)
+		}
+		statements = append(statements, stmt)
+	}
+	return statements, nil
+}
+
+// field is one tag and its value; continuation lines are joined with "\n"
+type field struct {
+	tag   string
+	value string
+}
+
+// message is the fields of one statement message and the bank that sent it
+type message struct {
+	senderBIC string
+	fields    []field
+}
+
+func (m *message) reference() string {
+	for _, f := range m.fields {
+		if f.tag == "20" {
+			return f.value
+		}
+	}
+	return ""
+}
+
+// splitMessages reads the text blocks of a file into messages
+func splitMessages(r io.Reader) ([]*message, error) {
+	messages := make([]*message, 0)
+	var current *message
+	senderBIC := ""
+
+	closeMessage := func() {
+		if current != nil && len(current.fields) > 0 {
+			messages = append(messages, current)
+		}
+		current = nil
+	}
+
+	scanner := bufio.NewScanner(r)
+	scanner.Buffer(make([]byte, 64*1024), 1024*1024)
+	for scanner.Scan() {
+		line := strings.TrimRight(scanner.Text(), "\r ")
+
+		// Header blocks come before the text block: {1:...}{2:...}{3:...}{4:
+		if header, rest, ok := strings.Cut(line, "{4:"); ok {
+			closeMessage()
+			senderBIC = headerSenderBIC(header)
+			line = rest
+		}
+
+		// End of the text block: "-}" optionally followed by trailer blocks, or a lone "-"
+		if strings.HasPrefix(line, "-}") || line == "-" {
+			closeMessage()
+			senderBIC = ""
+			continue
+		}
+		if strings.TrimSpace(line) == "" {
+			continue
+		}
+
+		match := tagPattern.FindStringSubmatch(line)
+		if match == nil {
+			if current == nil || len(current.fields) == 0 {
+				return nil, fmt.Errorf("unexpected line outside a field: %q", line)
+			}
+			last := &current.fields[len(current.fields)-1]
+			last.value += "\n" + line
+			continue
+		}
+
+		tag := match[1]
+		if tag == "20" {
+			closeMessage()
+		}
+		if current == nil {
+			current = &message{senderBIC: senderBIC}
+		}
+		current.fields = append(current.fields, field{tag: tag, value: line[len(match[0]):]})
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("failed to read MT940: %w", err)
+	}
+
+	closeMessage()
+	return messages, nil
+}
+
+// headerSenderBIC returns the sending bank's BIC from an output application header
+// ({2:O940 + input time + MIR date + sender's logical terminal}), or "" when there is none
+func headerSenderBIC(header string) string {
+	_, block2, ok := strings.Cut(header, "{2:O")
+	if !ok || len(block2) < 3+4+6+8 {
+		return ""
+	}
+	return block2[3+4+6 : 3+4+6+8]
+}
+
+func parseMessage(msg *message, filePath, source, jobID, fileID string) (*AccountStatement, error) {
+	if name, ok := domain.BankFromBIC(msg.senderBIC); ok {
+		source = name
+	}
+	if source == "" {
+		return nil, fmt.Errorf("sending bank is not identified")
+	}
+
+	stmt := statement.NewStatement(source, filePath, domain.DefaultCurrency)
+	stmt.Metadata["transactionReference"] = msg.reference()
+	result := &AccountStatement{Statement: stmt, Transactions: make([]*transaction.Transaction, 0)}
+
+	var last *transaction.Transaction
+	for _, f := range msg.fields {
+		switch f.tag {
+		case "25":
+			stmt.AccountNumber = strings.TrimSpace(f.value)
+		case "28C", "28":
+			stmt.Metadata["statementNumber"] = strings.TrimSpace(f.value)
+		case "60F", "60M":
+			balance, date, err := parseBalance(f.value)
+			if err != nil {
+				return nil, fmt.Errorf(":%s: %w", f.tag, err)
+			}
+			stmt.OpeningBalance = &balance
+			stmt.Currency = balance.Currency
+			stmt.Period = date.Format("2006-01-02")
+		case "62F", "62M":
+			balance, date, err := parseBalance(f.value)
+			if err != nil {
+				return nil, fmt.Errorf(":%s: %w", f.tag, err)
+			}
+			stmt.ClosingBalance = &balance
+			if stmt.Period != "" {
+				stmt.Period += " - " + date.Format("2006-01-02")
+			}
+		case "61":
+			txn, err := parseStatementLine(f.value, stmt.Currency, source, jobID, fileID)
+			if err != nil {
+				return nil, fmt.Errorf("statement line %d: %w", len(result.Transactions)+1, err)
+			}
+			// Customer references repeat across lines, e.g. two payments of one invoice,
+			// so lines are identified by their place in the statement
+			if msg.reference() != "" || txn.ID == "" {
+				txn.ID = fmt.Sprintf("%s-%d", msg.reference(), len(result.Transactions)+1)
+			}
+			txn.RawData["statementReference"] = msg.reference()
+			result.Transactions = append(result.Transactions, txn)
+			last = txn
+		case "86":
+			// The narrative belongs to the statement line before it; after the
+			// closing balance it is information for the whole statement
+			if last != nil && stmt.ClosingBalance == nil {
+				last.RawData["narrative"] = strings.ReplaceAll(f.value, "\n", " ")
+				last = nil
+			} else {
+				stmt.Metadata["narrative"] = strings.ReplaceAll(f.value, "\n", " ")
+			}
+		}
+	}
+
+	return result, nil
+}
+
+// parseStatementLine converts a :61: field into a bank transaction
+func parseStatementLine(value string, currency domain.Currency, source, jobID, fileID string) (*transaction.Transaction, error) {
+	match := statementLinePattern.FindStringSubmatch(value)
+	if match == nil {
+		return nil, fmt.Errorf("invalid statement line %q", value)
+	}
+	valueDateStr, entryDateStr, mark, fundsCode := match[1], match[2], match[3], match[4]
+	amountStr, typeCode := match[5], match[6]
+	customerRef, bankRef, details := strings.TrimSpace(match[7]), strings.TrimSpace(match[8]), strings.TrimSpace(match[9])
+
+	valueDate, err := time.Parse("060102", valueDateStr)
+	if err != nil {
+		return nil, fmt.Errorf("invalid value date %q", valueDateStr)
+	}
+	date := valueDate
+	if entryDateStr != "" {
+		if date, err = entryDate(valueDate, entryDateStr); err != nil {
+			return nil, err
+		}
+	}
+
+	amount, err := parseAmount(amountStr, currency)
+	if err != nil {
+		return nil, err
+	}
+
+	// Debits and reversals of credits take money out of the account
+	txnType := domain.TransactionTypeCredit
+	if mark == "D" || mark == "RC" {
+		amount = amount.Neg()
+		txnType = domain.TransactionTypeDebit
+	}
+
+	txn := transaction.NewTransaction(jobID, fileID, domain.SourceTypeBank, date, amount, txnType, source)
+
+	// NONREF means the account owner gave no reference
+	if strings.EqualFold(customerRef, "NONREF") {
+		customerRef = ""
+	}
+	txn.ID = bankRef
+	if txn.ID == "" {
+		txn.ID = customerRef
+	}
+
+	txn.RawData = map[string]any{
+		"customerReference":    customerRef,
+		"bankReference":        bankRef,
+		"supplementaryDetails": details,
+		"transactionType":      typeCode,
+		"mark":                 mark,
+		"fundsCode":            fundsCode,
+		"amount":               amountStr,
+		"currency":             string(currency),
+		"valueDate":            valueDate.Format("2006-01-02"),
+		"entryDate":            date.Format("2006-01-02"),
+		"reversal":             strings.HasPrefix(mark, "R"),
+		"bankSource":           source,
+	}
+
+	txn.NormalizeAmount()
+	return txn, nil
+}
+
+// entryDate places an MMDD entry date in the year of the value date. Entries booked
+// around new year can fall in the year before or after it.
+func entryDate(valueDate time.Time, mmdd string) (time.Time, error) {
+	date, err := time.Parse("20060102", fmt.Sprintf("%04d%s", valueDate.Year(), mmdd))
+	if err != nil {
+		return time.Time{}, fmt.Errorf("invalid entry date %q", mmdd)
+	}
+
+	switch {
+	case valueDate.Month() == time.December && date.Month() == time.January:
+		date = date.AddDate(1, 0, 0)
+	case valueDate.Month() == time.January && date.Month() == time.December:
+		date = date.AddDate(-1, 0, 0)
+	}
+	return date, nil
+}
+
+// parseBalance parses a balance field: debit/credit mark, date, currency and amount
+func parseBalance(value string) (domain.Money, time.Time, error) {
+	match := balancePattern.FindStringSubmatch(strings.TrimSpace(value))
+	if match == nil {
+		return domain.Money{}, time.Time{}, fmt.Errorf("invalid balance %q", value)
+	}
+
+	date, err := time.Parse("060102", match[2])
+	if err != nil {
+		return domain.Money{}, time.Time{}, fmt.Errorf("invalid balance date %q", match[2])
+	}
+
+	amount, err := parseAmount(match[4], domain.Currency(match[3]))
+	if err != nil {
+		return domain.Money{}, time.Time{}, err
+	}
+	if match[1] == "D" {
+		amount = amount.Neg()
+	}
+	return amount, date, nil
+}
+
+// parseAmount parses an MT940 amount, which uses a decimal comma, e.g. "150,50" or "1000,"
+func parseAmount(value string, currency domain.Currency) (domain.Money, error) {
+	return domain.ParseMoney(strings.Replace(value, ",", ".", 1), currency)
+}
@@ -0,0 +1,188 @@
+package mt940
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/farhaan/amartha-reconcile-system/internal/domain"
+	"github.com/farhaan/amartha-reconcile-system/internal/domain/statement"
+)
+
+// Two wrapped messages from BCA, the second one for a USD account
+const testMT940 = `{1:F01AMRTIDJAAXXX0000000000}{2:O9401200240322CENAIDJAAXXX00000000002403221200N}{4:
+:20:STMT240322
+:25:0123456789
+:28C:00001/001
+:60F:C240315IDR20000,00
+:61:2403150315D150,50NTRFTRX001//BCA_TX_001
+TRANSFER OUT
+:86:PAYMENT TO
+ VENDOR A
+:61:2403160316C1000,NTRFNONREF//BCA_TX_002
+:61:240318RC250,00NCHGNONREF
+:62F:C240322IDR20599,50
+:86:END OF STATEMENT
+-}{5:{CHK:123456789ABC}}
+{1:F01AMRTIDJAAXXX0000000000}{2:O9401200240322CENAIDJAAXXX00000000002403221200N}{4:
+:20:STMT240322USD
+:25:9876543210
+:28C:00001/001
+:60M:D240315USD10,00
+:61:2401011230C12,34FMSCREF-USD
+:62M:C240322USD2,34
+-}`
+
+func TestParse_WrappedMessages(t *testing.T) {
+	statements, err := Parse(strings.NewReader(testMT940), "stmt.sta", "", "job", "file")
+	if err != nil {
+		t.Fatalf("Parse failed: %v", err)
+	}
+	if len(statements) != 2 {
+		t.Fatalf("Expected 2 statements, got %d", len(statements))
+	}
+
+	stmt := statements[0].Statement
+	if stmt.Source != "BCA" {
+		t.Errorf("Expected source BCA from the sender BIC, got %s", stmt.Source)
+	}
+	if stmt.AccountNumber != "0123456789" {
+		t.Errorf("Expected account 0123456789, got %s", stmt.AccountNumber)
+	}
+	if stmt.Metadata["narrative"] != "END OF STATEMENT" {
+		t.Errorf("Expected the trailing :86: on the statement, got %q", stmt.Metadata["narrative"])
+	}
+
+	tests := []struct {
+		id          string
+		minor       int64
+		txnType     domain.TransactionType
+		date        string
+		customerRef string
+		bankRef     string
+	}{
+		{"STMT240322-1", -15050, domain.TransactionTypeDebit, "2024-03-15", "TRX001", "BCA_TX_001"},
+		{"STMT240322-2", 100000, domain.TransactionTypeCredit, "2024-03-16", "", "BCA_TX_002"},
+		{"STMT240322-3", -25000, domain.TransactionTypeDebit, "2024-03-18", "", ""},
+	}
+
+	txns := statements[0].Transactions
+	if len(txns) != len(tests) {
+		t.Fatalf("Expected %d transactions, got %d", len(tests), len(txns))
+	}
+	for i, tt := range tests {
+		txn := txns[i]
+		if txn.ID != tt.id {
+			t.Errorf("line %d: expected ID %s, got %s", i+1, tt.id, txn.ID)
+		}
+		if txn.Amount.Minor != tt.minor {
+			t.Errorf("line %d: expected amount %d, got %d", i+1, tt.minor, txn.Amount.Minor)
+		}
+		if txn.Type != tt.txnType {
+			t.Errorf("line %d: expected type %s, got %s", i+1, tt.txnType, txn.Type)
+		}
+		if got := txn.TransactionDate.Format(time.DateOnly); got != tt.date {
+			t.Errorf("line %d: expected date %s, got %s", i+1, tt.date, got)
+		}
+		if txn.RawData["customerReference"] != tt.customerRef || txn.RawData["bankReference"] != tt.bankRef {
+			t.Errorf("line %d: expected references %q // %q, got %q // %q", i+1,
+				tt.customerRef, tt.bankRef, txn.RawData["customerReference"], txn.RawData["bankReference"])
+		}
+	}
+
+	if txns[0].RawData["narrative"] != "PAYMENT TO  VENDOR A" {
+		t.Errorf("Expected the :86: narrative on the first line, got %q", txns[0].RawData["narrative"])
+	}
+	if txns[0].RawData["supplementaryDetails"] != "TRANSFER OUT" {
+		t.Errorf("Expected supplementary details, got %q", txns[0].RawData["supplementaryDetails"])
+	}
+	if txns[2].RawData["reversal"] != true {
+		t.Error("Expected the RC line to be a reversal")
+	}
+
+	amounts := make([]domain.Money, len(txns))
+	for i, txn := range txns {
+		amounts[i] = txn.Amount
+	}
+	if check := stmt.VerifyBalances(amounts); check.Status != statement.BalanceVerified {
+		t.Errorf("Expected the first statement to verify, got %s: %s", check.Status, check.Reason)
+	}
+
+	usd := statements[1]
+	if usd.Statement.Currency != "USD" || usd.Statement.OpeningBalance.Minor != -1000 {
+		t.Errorf("Expected USD opening balance -10.00, got %v", usd.Statement.OpeningBalance)
+	}
+	// Booked on 30 December for a value date of 1 January
+	if got := usd.Transactions[0].TransactionDate.Format(time.DateOnly); got != "2023-12-30" {
+		t.Errorf("Expected entry date 2023-12-30, got %s", got)
+	}
+	if usd.Transactions[0].Amount.Currency != "USD" {
+		t.Errorf("Expected a USD amount, got %s", usd.Transactions[0].Amount.Currency)
+	}
+}
+
+func TestParse_BareMessages(t *testing.T) {
+	input := ":20:A\n:60F:C240315IDR0,\n:61:240315C5,NTRFREF1\n:62F:C240315IDR5,\n" +
+		":20:B\n:60F:C240316IDR5,\n:61:2403160316D5,NTRFREF2\n:62F:C240316IDR0,\n"
+
+	statements, err := Parse(strings.NewReader(input), "mandiri.sta", "MANDIRI", "job", "file")
+	if err != nil {
+		t.Fatalf("Parse failed: %v", err)
+	}
+	if len(statements) != 2 {
+		t.Fatalf("Expected 2 statements, got %d", len(statements))
+	}
+	for i, s := range statements {
+		if s.Statement.Source != "MANDIRI" {
+			t.Errorf("statement %d: expected the given source, got %s", i+1, s.Statement.Source)
+		}
+		if len(s.Transactions) != 1 {
+			t.Errorf("statement %d: expected 1 transaction, got %d", i+1, len(s.Transactions))
+		}
+	}
+}
+
+func TestParse_RepeatedCustomerReference(t *testing.T) {
+	// Two payments against one invoice carry the same customer reference
+	input := ":20:STMT1\n:60F:C240315IDR0,\n:61:240315C100,NTRFINV42\n:61:240315C200,NTRFINV42\n:62F:C240315IDR300,\n"
+
+	statements, err := Parse(strings.NewReader(input), "bca.sta", "BCA", "job", "file")
+	if err != nil {
+		t.Fatalf("Parse failed: %v", err)
+	}
+
+	txns := statements[0].Transactions
+	if len(txns) != 2 {
+		t.Fatalf("Expected 2 transactions, got %d", len(txns))
+	}
+	if txns[0].ID == txns[1].ID {
+		t.Errorf("Expected distinct IDs, got %s twice", txns[0].ID)
+	}
+	for i, txn := range txns {
+		if txn.RawData["customerReference"] != "INV42" {
+			t.Errorf("line %d: expected customer reference INV42, got %q", i+1, txn.RawData["customerReference"])
+		}
+	}
+}
+
+func TestParse_Invalid(t *testing.T) {
+	tests := map[string]string{
+		"empty":          "",
+		"no source":      ":20:A\n:61:240315C5,NTRFREF1\n",
+		"stray text":     "hello\n:20:A\n",
+		"bad line":       ":20:A\n:61:240315X5,NTRFREF1\n",
+		"bad date":       ":20:A\n:61:241315C5,NTRFREF1\n",
+		"bad entry date": ":20:A\n:61:2403151315C5,NTRFREF1\n",
+		"bad balance":    ":20:A\n:60F:C240315IDR1.000,00\n",
+	}
+
+	for name, input := range tests {
+		source := "BCA"
+		if name == "no source" {
+			source = ""
+		}
+		if _, err := Parse(strings.NewReader(input), "x.sta", source, "job", "file"); err == nil {
+			t.Errorf("%s: expected error", name)
+		}
+	}
+}
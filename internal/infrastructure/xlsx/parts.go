@@ -0,0 +1,206 @@
+package xlsx
+
+import (
+	"archive/zip"
+	"encoding/xml"
+	"math"
+	"path"
+	"strings"
+	"time"
+)
+
+type workbookXML struct {
+	Properties struct {
+		Date1904 bool `xml:"date1904,attr"`
+	} `xml:"workbookPr"`
+	Sheets []sheetXML `xml:"sheets>sheet"`
+}
+
+type sheetXML struct {
+	Name  string     `xml:"name,attr"`
+	Attrs []xml.Attr `xml:",any,attr"`
+}
+
+// relationshipID returns the r:id attribute. Transitional and strict workbooks use
+// different namespaces for it, so any namespaced "id" is accepted.
+func (s sheetXML) relationshipID() string {
+	for _, attr := range s.Attrs {
+		if attr.Name.Local == "id" && attr.Name.Space != "" {
+			return attr.Value
+		}
+	}
+	return ""
+}
+
+type relationshipsXML struct {
+	Relationships []struct {
+		ID     string `xml:"Id,attr"`
+		Type   string `xml:"Type,attr"`
+		Target string `xml:"Target,attr"`
+	} `xml:"Relationship"`
+}
+
+// target returns the archive path of a workbook relationship
+func (r relationshipsXML) target(id string) string {
+	for _, rel := range r.Relationships {
+		if rel.ID == id {
+			return resolveTarget(rel.Target)
+		}
+	}
+	return ""
+}
+
+// targetOfType returns the archive path of the first relationship whose type ends in kind
+func (r relationshipsXML) targetOfType(kind string) string {
+	for _, rel := range r.Relationships {
+		if strings.HasSuffix(rel.Type, "/"+kind) {
+			return resolveTarget(rel.Target)
+		}
+	}
+	return ""
+}
+
+// resolveTarget turns a relationship target into an archive path. Targets are
+// relative to xl/ unless they start with a slash.
+func resolveTarget(target string) string {
+	if strings.HasPrefix(target, "/") {
+		return strings.TrimPrefix(target, "/")
+	}
+	return path.Join("xl", target)
+}
+
+type rowXML struct {
+	R     string    `xml:"r,attr"`
+	Cells []cellXML `xml:"c"`
+}
+
+type cellXML struct {
+	Ref    string   `xml:"r,attr"`
+	Type   string   `xml:"t,attr"`
+	Style  int      `xml:"s,attr"`
+	Value  string   `xml:"v"`
+	Inline *textXML `xml:"is"`
+}
+
+// textXML is a string item: plain text, or rich text runs. Phonetic runs are ignored.
+type textXML struct {
+	T    string `xml:"t"`
+	Runs []struct {
+		T string `xml:"t"`
+	} `xml:"r"`
+}
+
+func (t textXML) text() string {
+	var b strings.Builder
+	b.WriteString(t.T)
+	for _, run := range t.Runs {
+		b.WriteString(run.T)
+	}
+	return b.String()
+}
+
+// readSharedStrings loads the shared string table
+func readSharedStrings(archive *zip.ReadCloser, name string) ([]string, error) {
+	var table struct {
+		Items []textXML `xml:"si"`
+	}
+	if err := decodePart(archive, name, &table); err != nil {
+		return nil, err
+	}
+
+	shared := make([]string, len(table.Items))
+	for i, item := range table.Items {
+		shared[i] = item.text()
+	}
+	return shared, nil
+}
+
+// readDateStyles returns the cell style indexes whose number format shows a date or time
+func readDateStyles(archive *zip.ReadCloser, name string) (map[int]bool, error) {
+	var styles struct {
+		NumFmts []struct {
+			ID   int    `xml:"numFmtId,attr"`
+			Code string `xml:"formatCode,attr"`
+		} `xml:"numFmts>numFmt"`
+		CellXfs []struct {
+			NumFmtID int `xml:"numFmtId,attr"`
+		} `xml:"cellXfs>xf"`
+	}
+	if err := decodePart(archive, name, &styles); err != nil {
+		return nil, err
+	}
+
+	custom := make(map[int]string)
+	for _, numFmt := range styles.NumFmts {
+		custom[numFmt.ID] = numFmt.Code
+	}
+
+	dateStyles := make(map[int]bool)
+	for i, xf := range styles.CellXfs {
+		if code, ok := custom[xf.NumFmtID]; ok {
+			dateStyles[i] = isDateFormat(code)
+		} else {
+			dateStyles[i] = isBuiltinDateFormat(xf.NumFmtID)
+		}
+	}
+	return dateStyles, nil
+}
+
+// isBuiltinDateFormat reports whether a built-in number format ID is a date or time format
+func isBuiltinDateFormat(id int) bool {
+	return (id >= 14 && id <= 22) || (id >= 45 && id <= 47)
+}
+
+// isDateFormat reports whether a custom format code shows a date or time. Quoted text,
+// escaped characters and [bracketed] sections such as colours are ignored.
+func isDateFormat(code string) bool {
+	inQuote, inBracket, escaped := false, false, false
+	for _, ch := range code {
+		switch {
+		case escaped:
+			escaped = false
+		case ch == '\\':
+			escaped = true
+		case ch == '"':
+			inQuote = !inQuote
+		case inQuote:
+		case ch == '[':
+			inBracket = true
+		case ch == ']':
+			inBracket = false
+		case inBracket:
+		case strings.ContainsRune("dmyhsDMYHS", ch):
+			return true
+		}
+	}
+	return false
+}
+
+// Excel day zero. The 1900 system is off by one because Excel treats 1900 as a leap year,
+// which the 1899-12-30 epoch absorbs for every date from March 1900 on.
+var (
+	epoch1900 = time.Date(1899, 12, 30, 0, 0, 0, 0, time.UTC)
+	epoch1904 = time.Date(1904, 1, 1, 0, 0, 0, 0, time.UTC)
+)
+
+// SerialToTime converts an Excel serial date (days since the epoch, with the time
+// of day as a fraction) to a UTC time rounded to the second
+func SerialToTime(serial float64, date1904 bool) time.Time {
+	epoch := epoch1900
+	if date1904 {
+		epoch = epoch1904
+	}
+
+	days := math.Floor(serial)
+	seconds := math.Round((serial - days) * 86400)
+	return epoch.AddDate(0, 0, int(days)).Add(time.Duration(seconds) * time.Second)
+}
+
+// FormatSerial formats an Excel serial date as 2006-01-02, or as RFC3339 when it has a time of day
+func FormatSerial(serial float64, date1904 bool) string {
+	t := SerialToTime(serial, date1904)
+	if t.Hour() == 0 && t.Minute() == 0 && t.Second() == 0 {
+		return t.Format("2006-01-02")
+	}
+	return t.Format(time.RFC3339)
+}
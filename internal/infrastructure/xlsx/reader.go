@@ -0,0 +1,259 @@
+package xlsx
+
+import (
+	"archive/zip"
+	"encoding/xml"
+	"fmt"
+	"io"
+	"path"
+	"strconv"
+	"strings"
+)
+
+// SheetReader streams the rows of one worksheet as string records, like encoding/csv.
+// Shared strings are resolved, date-formatted cells are converted to ISO dates, and
+// trailing empty cells are dropped. Rows without any value are skipped.
+type SheetReader struct {
+	archive    *zip.ReadCloser
+	sheet      io.ReadCloser
+	decoder    *xml.Decoder
+	shared     []string
+	dateStyles map[int]bool // Cell style indexes with a date number format
+	date1904   bool
+	sheetName  string
+}
+
+// Open opens an XLSX workbook and returns a reader over the named sheet.
+// An empty sheet name selects the first sheet in the workbook.
+func Open(filePath, sheetName string) (*SheetReader, error) {
+	archive, err := zip.OpenReader(filePath)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open file %s: %w", filePath, err)
+	}
+
+	r, err := openSheet(archive, sheetName)
+	if err != nil {
+		archive.Close()
+		return nil, fmt.Errorf("failed to open %s: %w", filePath, err)
+	}
+	return r, nil
+}
+
+func openSheet(archive *zip.ReadCloser, sheetName string) (*SheetReader, error) {
+	var workbook workbookXML
+	if err := decodePart(archive, "xl/workbook.xml", &workbook); err != nil {
+		return nil, err
+	}
+	if len(workbook.Sheets) == 0 {
+		return nil, fmt.Errorf("workbook has no sheets")
+	}
+
+	var rels relationshipsXML
+	if err := decodePart(archive, "xl/_rels/workbook.xml.rels", &rels); err != nil {
+		return nil, err
+	}
+
+	var selected *sheetXML
+	for i := range workbook.Sheets {
+		if sheetName == "" || strings.EqualFold(workbook.Sheets[i].Name, sheetName) {
+			selected = &workbook.Sheets[i]
+			break
+		}
+	}
+	if selected == nil {
+		return nil, fmt.Errorf("sheet %q not found", sheetName)
+	}
+
+	sheetPath := rels.target(selected.relationshipID())
+	if sheetPath == "" {
+		return nil, fmt.Errorf("sheet %q has no worksheet part", selected.Name)
+	}
+
+	r := &SheetReader{
+		archive:   archive,
+		sheetName: selected.Name,
+		date1904:  workbook.Properties.Date1904,
+	}
+
+	var err error
+	if target := rels.targetOfType("sharedStrings"); target != "" {
+		if r.shared, err = readSharedStrings(archive, target); err != nil {
+			return nil, err
+		}
+	}
+	if target := rels.targetOfType("styles"); target != "" {
+		if r.dateStyles, err = readDateStyles(archive, target); err != nil {
+			return nil, err
+		}
+	}
+
+	if r.sheet, err = openPart(archive, sheetPath); err != nil {
+		return nil, err
+	}
+	r.decoder = xml.NewDecoder(r.sheet)
+	return r, nil
+}
+
+// Read returns the next non-empty row of the sheet, or io.EOF after the last one
+func (r *SheetReader) Read() ([]string, error) {
+	for {
+		token, err := r.decoder.Token()
+		if err != nil {
+			return nil, err
+		}
+
+		start, ok := token.(xml.StartElement)
+		if !ok || start.Name.Local != "row" {
+			continue
+		}
+
+		var row rowXML
+		if err := r.decoder.DecodeElement(&row, &start); err != nil {
+			return nil, fmt.Errorf("sheet %s: %w", r.sheetName, err)
+		}
+
+		record, err := r.record(row)
+		if err != nil {
+			return nil, fmt.Errorf("sheet %s row %s: %w", r.sheetName, row.R, err)
+		}
+		if len(record) > 0 {
+			return record, nil
+		}
+	}
+}
+
+// Close closes the sheet and the workbook
+func (r *SheetReader) Close() error {
+	if r.sheet != nil {
+		r.sheet.Close()
+	}
+	return r.archive.Close()
+}
+
+// SheetName returns the name of the sheet being read
+func (r *SheetReader) SheetName() string {
+	return r.sheetName
+}
+
+// record converts a row into strings placed at their column positions
+func (r *SheetReader) record(row rowXML) ([]string, error) {
+	record := make([]string, 0, len(row.Cells))
+	for i, cell := range row.Cells {
+		col := i
+		if cell.Ref != "" {
+			var err error
+			if col, err = columnIndex(cell.Ref); err != nil {
+				return nil, err
+			}
+		}
+		for len(record) < col {
+			record = append(record, "")
+		}
+
+		value, err := r.value(cell)
+		if err != nil {
+			return nil, fmt.Errorf("cell %s: %w", cell.Ref, err)
+		}
+		if col < len(record) {
+			record[col] = value
+		} else {
+			record = append(record, value)
+		}
+	}
+
+	for len(record) > 0 && strings.TrimSpace(record[len(record)-1]) == "" {
+		record = record[:len(record)-1]
+	}
+	return record, nil
+}
+
+// value returns the text of a cell
+func (r *SheetReader) value(cell cellXML) (string, error) {
+	switch cell.Type {
+	case "s":
+		i, err := strconv.Atoi(strings.TrimSpace(cell.Value))
+		if err != nil || i < 0 || i >= len(r.shared) {
+			return "", fmt.Errorf("invalid shared string index %q", cell.Value)
+		}
+		return r.shared[i], nil
+	case "inlineStr":
+		if cell.Inline == nil {
+			return "", nil
+		}
+		return cell.Inline.text(), nil
+	case "b":
+		if cell.Value == "1" {
+			return "TRUE", nil
+		}
+		return "FALSE", nil
+	case "str", "e":
+		return cell.Value, nil
+	}
+
+	// Numbers: dates are serials with a date style, everything else is a plain decimal
+	if cell.Value == "" {
+		return "", nil
+	}
+	if r.dateStyles[cell.Style] {
+		serial, err := strconv.ParseFloat(cell.Value, 64)
+		if err != nil {
+			return "", fmt.Errorf("invalid date serial %q", cell.Value)
+		}
+		return FormatSerial(serial, r.date1904), nil
+	}
+	return plainNumber(cell.Value), nil
+}
+
+// plainNumber rewrites numbers Excel stored in scientific notation as plain decimals
+func plainNumber(s string) string {
+	if !strings.ContainsAny(s, "eE") {
+		return s
+	}
+	f, err := strconv.ParseFloat(s, 64)
+	if err != nil {
+		return s
+	}
+	return strconv.FormatFloat(f, 'f', -1, 64)
+}
+
+// columnIndex converts a cell reference like "AB12" to a 0-based column index
+func columnIndex(ref string) (int, error) {
+	col := 0
+	n := 0
+	for _, ch := range ref {
+		if ch < 'A' || ch > 'Z' {
+			break
+		}
+		col = col*26 + int(ch-'A'+1)
+		n++
+	}
+	if n == 0 {
+		return 0, fmt.Errorf("invalid cell reference %q", ref)
+	}
+	return col - 1, nil
+}
+
+// decodePart decodes a whole XML part of the archive
+func decodePart(archive *zip.ReadCloser, name string, v any) error {
+	file, err := openPart(archive, name)
+	if err != nil {
+		return err
+	}
+	defer file.Close()
+
+	if err := xml.NewDecoder(file).Decode(v); err != nil {
+		return fmt.Errorf("failed to parse %s: %w", name, err)
+	}
+	return nil
+}
+
+// openPart opens a part of the archive by name
+func openPart(archive *zip.ReadCloser, name string) (io.ReadCloser, error) {
+	name = strings.TrimPrefix(path.Clean(name), "/")
+	for _, file := range archive.File {
+		if file.Name == name {
+			return file.Open()
+		}
+	}
+	return nil, fmt.Errorf("missing %s, not an XLSX workbook", name)
+}
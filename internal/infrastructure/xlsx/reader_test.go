@@ -0,0 +1,193 @@
+package xlsx
+
+import (
+	"archive/zip"
+	"io"
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+	"time"
+)
+
+const testSheet1 = `<worksheet xmlns="http://schemas.openxmlformats.org/spreadsheetml/2006/main"><sheetData>
+<row r="1"><c r="A1" t="s"><v>0</v></c><c r="B1" t="s"><v>1</v></c><c r="C1" t="s"><v>2</v></c><c r="D1" s="0"/></row>
+<row r="2"><c r="A2" t="inlineStr"><is><t>TX001</t></is></c><c r="B2"><v>-150.5</v></c><c r="C2" s="1"><v>45366</v></c></row>
+<row r="3"></row>
+<row r="4"><c r="A4" t="s"><v>3</v></c><c r="C4" s="2"><v>45367.4375</v></c></row>
+<row r="5"><c r="B5"><v>1.5E-2</v></c></row>
+</sheetData></worksheet>`
+
+const testSheet2 = `<worksheet xmlns="http://schemas.openxmlformats.org/spreadsheetml/2006/main"><sheetData>
+<row r="1"><c r="A1" t="inlineStr"><is><t>second</t></is></c></row>
+</sheetData></worksheet>`
+
+func TestSheetReader_Read(t *testing.T) {
+	path := writeTestWorkbook(t, false)
+
+	reader, err := Open(path, "")
+	if err != nil {
+		t.Fatalf("Open failed: %v", err)
+	}
+	defer reader.Close()
+
+	if reader.SheetName() != "Mutasi" {
+		t.Errorf("Expected first sheet Mutasi, got %s", reader.SheetName())
+	}
+
+	want := [][]string{
+		{"unique_identifier", "amount", "date"},
+		{"TX001", "-150.5", "2024-03-15"},
+		{"TX002", "", "2024-03-16T10:30:00Z"},
+		{"", "0.015"},
+	}
+
+	for i, expected := range want {
+		record, err := reader.Read()
+		if err != nil {
+			t.Fatalf("row %d: Read failed: %v", i+1, err)
+		}
+		if !reflect.DeepEqual(record, expected) {
+			t.Errorf("row %d: expected %q, got %q", i+1, expected, record)
+		}
+	}
+
+	if _, err := reader.Read(); err != io.EOF {
+		t.Errorf("Expected io.EOF after the last row, got %v", err)
+	}
+}
+
+func TestSheetReader_SelectSheet(t *testing.T) {
+	path := writeTestWorkbook(t, false)
+
+	reader, err := Open(path, "summary")
+	if err != nil {
+		t.Fatalf("Open failed: %v", err)
+	}
+	defer reader.Close()
+
+	record, err := reader.Read()
+	if err != nil {
+		t.Fatalf("Read failed: %v", err)
+	}
+	if !reflect.DeepEqual(record, []string{"second"}) {
+		t.Errorf("Expected the Summary sheet, got %q", record)
+	}
+
+	if _, err := Open(path, "missing"); err == nil {
+		t.Error("Expected error for an unknown sheet")
+	}
+}
+
+func TestSheetReader_Date1904(t *testing.T) {
+	path := writeTestWorkbook(t, true)
+
+	reader, err := Open(path, "")
+	if err != nil {
+		t.Fatalf("Open failed: %v", err)
+	}
+	defer reader.Close()
+
+	reader.Read() // header
+	record, err := reader.Read()
+	if err != nil {
+		t.Fatalf("Read failed: %v", err)
+	}
+	if record[2] != "2028-03-16" {
+		t.Errorf("Expected serial 45366 in the 1904 system to be 2028-03-16, got %s", record[2])
+	}
+}
+
+func TestSerialToTime(t *testing.T) {
+	tests := []struct {
+		serial float64
+		want   time.Time
+	}{
+		{61, time.Date(1900, 3, 1, 0, 0, 0, 0, time.UTC)},
+		{45366, time.Date(2024, 3, 15, 0, 0, 0, 0, time.UTC)},
+		{45366.5, time.Date(2024, 3, 15, 12, 0, 0, 0, time.UTC)},
+		{45366.99999999, time.Date(2024, 3, 16, 0, 0, 0, 0, time.UTC)},
+	}
+
+	for _, tt := range tests {
+		if got := SerialToTime(tt.serial, false); !got.Equal(tt.want) {
+			t.Errorf("SerialToTime(%v) = %s, want %s", tt.serial, got, tt.want)
+		}
+	}
+}
+
+func TestIsDateFormat(t *testing.T) {
+	tests := map[string]bool{
+		"dd/mm/yyyy":             true,
+		"yyyy-mm-dd hh:mm":       true,
+		"#,##0.00":               false,
+		"[Red]#,##0.00":          false,
+		`0.00" days"`:            false,
+		`#,##0.00\d`:             false,
+		"[$-421]dd mmmm yyyy;@":  true,
+		"General":                false,
+		"0.00E+00":               false,
+		`_("Rp"* #,##0_);_(@_)`:  false,
+		`[h]:mm:ss;"elapsed"`:    true,
+		`"Tanggal: "dd-mmm-yyyy`: true,
+	}
+
+	for code, want := range tests {
+		if got := isDateFormat(code); got != want {
+			t.Errorf("isDateFormat(%q) = %v, want %v", code, got, want)
+		}
+	}
+}
+
+// writeTestWorkbook writes a two-sheet workbook: Mutasi with data and Summary with one cell.
+// Style 1 is the built-in date format, style 2 a custom date-time format.
+func writeTestWorkbook(t *testing.T, date1904 bool) string {
+	t.Helper()
+
+	workbookPr := ""
+	if date1904 {
+		workbookPr = `<workbookPr date1904="1"/>`
+	}
+
+	parts := map[string]string{
+		"xl/workbook.xml": `<workbook xmlns="http://schemas.openxmlformats.org/spreadsheetml/2006/main" ` +
+			`xmlns:r="http://schemas.openxmlformats.org/officeDocument/2006/relationships">` + workbookPr +
+			`<sheets><sheet name="Mutasi" sheetId="1" r:id="rId1"/><sheet name="Summary" sheetId="2" r:id="rId2"/></sheets></workbook>`,
+		"xl/_rels/workbook.xml.rels": `<Relationships xmlns="http://schemas.openxmlformats.org/package/2006/relationships">` +
+			`<Relationship Id="rId1" Type="http://schemas.openxmlformats.org/officeDocument/2006/relationships/worksheet" Target="worksheets/sheet1.xml"/>` +
+			`<Relationship Id="rId2" Type="http://schemas.openxmlformats.org/officeDocument/2006/relationships/worksheet" Target="/xl/worksheets/sheet2.xml"/>` +
+			`<Relationship Id="rId3" Type="http://schemas.openxmlformats.org/officeDocument/2006/relationships/sharedStrings" Target="sharedStrings.xml"/>` +
+			`<Relationship Id="rId4" Type="http://schemas.openxmlformats.org/officeDocument/2006/relationships/styles" Target="styles.xml"/>` +
+			`</Relationships>`,
+		"xl/sharedStrings.xml": `<sst xmlns="http://schemas.openxmlformats.org/spreadsheetml/2006/main">` +
+			`<si><t>unique_identifier</t></si><si><t>amount</t></si><si><r><t>da</t></r><r><t>te</t></r></si>` +
+			`<si><t>TX002</t><rPh><t>ignored</t></rPh></si></sst>`,
+		"xl/styles.xml": `<styleSheet xmlns="http://schemas.openxmlformats.org/spreadsheetml/2006/main">` +
+			`<numFmts><numFmt numFmtId="165" formatCode="yyyy-mm-dd hh:mm"/></numFmts>` +
+			`<cellXfs><xf numFmtId="0"/><xf numFmtId="14"/><xf numFmtId="165"/></cellXfs></styleSheet>`,
+		"xl/worksheets/sheet1.xml": testSheet1,
+		"xl/worksheets/sheet2.xml": testSheet2,
+	}
+
+	path := filepath.Join(t.TempDir(), "bca_statement_2024-03-15.xlsx")
+	file, err := os.Create(path)
+	if err != nil {
+		t.Fatalf("failed to create %s: %v", path, err)
+	}
+	defer file.Close()
+
+	archive := zip.NewWriter(file)
+	for name, content := range parts {
+		w, err := archive.Create(name)
+		if err != nil {
+			t.Fatalf("failed to add %s: %v", name, err)
+		}
+		if _, err := io.WriteString(w, content); err != nil {
+			t.Fatalf("failed to write %s: %v", name, err)
+		}
+	}
+	if err := archive.Close(); err != nil {
+		t.Fatalf("failed to close workbook: %v", err)
+	}
+	return path
+}
@@ -0,0 +1,173 @@
+package xlsx
+
+import (
+	"archive/zip"
+	"bytes"
+	"encoding/xml"
+	"fmt"
+	"io"
+	"os"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+// maxSheetName is the longest sheet name Excel accepts
+const maxSheetName = 31
+
+// plainDecimal matches the numbers written as number cells, e.g. "-150.50"
+var plainDecimal = regexp.MustCompile(`^-?[0-9]+(\.[0-9]+)?$`)
+
+// Sheet is one worksheet to write. Cells are text, except in the Numbers columns
+// where values that are plain decimals become number cells that can be summed.
+type Sheet struct {
+	Name    string
+	Rows    [][]string
+	Numbers []int // 0-based column indexes
+}
+
+// Write creates an XLSX workbook with the given sheets, in order
+func Write(filePath string, sheets []Sheet) error {
+	if len(sheets) == 0 {
+		return fmt.Errorf("workbook needs at least one sheet")
+	}
+	if err := validateSheetNames(sheets); err != nil {
+		return err
+	}
+
+	file, err := os.Create(filePath)
+	if err != nil {
+		return fmt.Errorf("failed to create file %s: %w", filePath, err)
+	}
+
+	if err := writeWorkbook(file, sheets); err != nil {
+		file.Close()
+		return fmt.Errorf("failed to write %s: %w", filePath, err)
+	}
+	return file.Close()
+}
+
+func validateSheetNames(sheets []Sheet) error {
+	seen := make(map[string]bool)
+	for _, sheet := range sheets {
+		switch {
+		case sheet.Name == "" || len([]rune(sheet.Name)) > maxSheetName:
+			return fmt.Errorf("sheet name %q must be 1 to %d characters", sheet.Name, maxSheetName)
+		case strings.ContainsAny(sheet.Name, `[]:*?/\`):
+			return fmt.Errorf("sheet name %q cannot contain any of []:*?/\\", sheet.Name)
+		case seen[strings.ToLower(sheet.Name)]:
+			return fmt.Errorf("duplicate sheet name %q", sheet.Name)
+		}
+		seen[strings.ToLower(sheet.Name)] = true
+	}
+	return nil
+}
+
+func writeWorkbook(w io.Writer, sheets []Sheet) error {
+	archive := zip.NewWriter(w)
+
+	var contentTypes, workbook, rels bytes.Buffer
+	contentTypes.WriteString(xml.Header)
+	contentTypes.WriteString(`<Types xmlns="http://schemas.openxmlformats.org/package/2006/content-types">`)
+	contentTypes.WriteString(`<Default Extension="rels" ContentType="application/vnd.openxmlformats-package.relationships+xml"/>`)
+	contentTypes.WriteString(`<Default Extension="xml" ContentType="application/xml"/>`)
+	contentTypes.WriteString(`<Override PartName="/xl/workbook.xml" ContentType="application/vnd.openxmlformats-officedocument.spreadsheetml.sheet.main+xml"/>`)
+
+	workbook.WriteString(xml.Header)
+	workbook.WriteString(`<workbook xmlns="http://schemas.openxmlformats.org/spreadsheetml/2006/main" xmlns:r="http://schemas.openxmlformats.org/officeDocument/2006/relationships"><sheets>`)
+
+	rels.WriteString(xml.Header)
+	rels.WriteString(`<Relationships xmlns="http://schemas.openxmlformats.org/package/2006/relationships">`)
+
+	for i, sheet := range sheets {
+		n := i + 1
+		fmt.Fprintf(&contentTypes, `<Override PartName="/xl/worksheets/sheet%d.xml" ContentType="application/vnd.openxmlformats-officedocument.spreadsheetml.worksheet+xml"/>`, n)
+		fmt.Fprintf(&workbook, `<sheet name="%s" sheetId="%d" r:id="rId%d"/>`, escape(sheet.Name), n, n)
+		fmt.Fprintf(&rels, `<Relationship Id="rId%d" Type="http://schemas.openxmlformats.org/officeDocument/2006/relationships/worksheet" Target="worksheets/sheet%d.xml"/>`, n, n)
+	}
+
+	contentTypes.WriteString(`</Types>`)
+	workbook.WriteString(`</sheets></workbook>`)
+	rels.WriteString(`</Relationships>`)
+
+	parts := []struct {
+		name    string
+		content []byte
+	}{
+		{"[Content_Types].xml", contentTypes.Bytes()},
+		{"_rels/.rels", []byte(xml.Header + `<Relationships xmlns="http://schemas.openxmlformats.org/package/2006/relationships">` +
+			`<Relationship Id="rId1" Type="http://schemas.openxmlformats.org/officeDocument/2006/relationships/officeDocument" Target="xl/workbook.xml"/>` +
+			`</Relationships>`)},
+		{"xl/workbook.xml", workbook.Bytes()},
+		{"xl/_rels/workbook.xml.rels", rels.Bytes()},
+	}
+	for _, part := range parts {
+		if err := writePart(archive, part.name, part.content); err != nil {
+			return err
+		}
+	}
+
+	for i, sheet := range sheets {
+		if err := writePart(archive, fmt.Sprintf("xl/worksheets/sheet%d.xml", i+1), sheetXMLBytes(sheet)); err != nil {
+			return err
+		}
+	}
+
+	return archive.Close()
+}
+
+func writePart(archive *zip.Writer, name string, content []byte) error {
+	part, err := archive.Create(name)
+	if err != nil {
+		return err
+	}
+	_, err = part.Write(content)
+	return err
+}
+
+// sheetXMLBytes renders a worksheet. Text is written as inline strings, so the
+// workbook needs no shared string table.
+func sheetXMLBytes(sheet Sheet) []byte {
+	numbers := make(map[int]bool, len(sheet.Numbers))
+	for _, col := range sheet.Numbers {
+		numbers[col] = true
+	}
+
+	var b bytes.Buffer
+	b.WriteString(xml.Header)
+	b.WriteString(`<worksheet xmlns="http://schemas.openxmlformats.org/spreadsheetml/2006/main"><sheetData>`)
+	for i, row := range sheet.Rows {
+		fmt.Fprintf(&b, `<row r="%d">`, i+1)
+		for col, value := range row {
+			if value == "" {
+				continue
+			}
+			ref := columnName(col) + strconv.Itoa(i+1)
+			// The header row stays text even in number columns
+			if i > 0 && numbers[col] && plainDecimal.MatchString(value) {
+				fmt.Fprintf(&b, `<c r="%s"><v>%s</v></c>`, ref, value)
+				continue
+			}
+			fmt.Fprintf(&b, `<c r="%s" t="inlineStr"><is><t xml:space="preserve">%s</t></is></c>`, ref, escape(value))
+		}
+		b.WriteString(`</row>`)
+	}
+	b.WriteString(`</sheetData></worksheet>`)
+	return b.Bytes()
+}
+
+// columnName converts a 0-based column index to its letters, e.g. 27 to "AB"
+func columnName(col int) string {
+	name := ""
+	for col++; col > 0; col = (col - 1) / 26 {
+		name = string(rune('A'+(col-1)%26)) + name
+	}
+	return name
+}
+
+// escape escapes text for XML, replacing characters XML cannot hold
+func escape(s string) string {
+	var b strings.Builder
+	xml.EscapeText(&b, []byte(s))
+	return b.String()
+}
@@ -0,0 +1,87 @@
+package xlsx
+
+import (
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestWrite_RoundTrip(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "exceptions.xlsx")
+	sheets := []Sheet{
+		{
+			Name: "Unmatched Bank",
+			Rows: [][]string{
+				{"id", "amount", "description"},
+				{"00123", "-150.50", "Transfer <BCA> & fee"},
+				{"TX002", "n/a", ""},
+			},
+			Numbers: []int{1},
+		},
+		{Name: "Summary", Rows: [][]string{{"  padded  "}}},
+	}
+
+	if err := Write(path, sheets); err != nil {
+		t.Fatalf("Write failed: %v", err)
+	}
+
+	reader, err := Open(path, "unmatched bank")
+	if err != nil {
+		t.Fatalf("Open failed: %v", err)
+	}
+	defer reader.Close()
+
+	// Leading zeros survive in text columns, the amount column reads back the same
+	want := [][]string{
+		{"id", "amount", "description"},
+		{"00123", "-150.50", "Transfer <BCA> & fee"},
+		{"TX002", "n/a"},
+	}
+	for i, expected := range want {
+		record, err := reader.Read()
+		if err != nil {
+			t.Fatalf("row %d: Read failed: %v", i+1, err)
+		}
+		if !reflect.DeepEqual(record, expected) {
+			t.Errorf("row %d: expected %q, got %q", i+1, expected, record)
+		}
+	}
+
+	summary, err := Open(path, "Summary")
+	if err != nil {
+		t.Fatalf("Open failed: %v", err)
+	}
+	defer summary.Close()
+	if record, _ := summary.Read(); len(record) != 1 || record[0] != "  padded  " {
+		t.Errorf("Expected whitespace to be preserved, got %q", record)
+	}
+}
+
+func TestWrite_InvalidSheetNames(t *testing.T) {
+	tests := [][]Sheet{
+		nil,
+		{{Name: ""}},
+		{{Name: strings.Repeat("x", 32)}},
+		{{Name: "a/b"}},
+		{{Name: "Sheet"}, {Name: "sheet"}},
+	}
+
+	for _, sheets := range tests {
+		if err := Write(filepath.Join(t.TempDir(), "bad.xlsx"), sheets); err == nil {
+			t.Errorf("Expected error for sheets %+v", sheets)
+		}
+	}
+}
+
+func TestColumnName(t *testing.T) {
+	tests := map[int]string{0: "A", 25: "Z", 26: "AA", 27: "AB", 701: "ZZ", 702: "AAA"}
+	for col, want := range tests {
+		if got := columnName(col); got != want {
+			t.Errorf("columnName(%d) = %s, want %s", col, got, want)
+		}
+		if back, _ := columnIndex(want + "1"); back != col {
+			t.Errorf("columnIndex(%s1) = %d, want %d", want, back, col)
+		}
+	}
+}
@@ -0,0 +1,227 @@
+package matcher
+
+import "math"
+
+// solveAssignment finds a minimum-cost one-to-one assignment for a rows x cols cost
+// matrix using the Hungarian algorithm. Every row is assigned when rows <= cols,
+// every column otherwise. Returns the column chosen for each row (-1 if none) and the total cost.
+func solveAssignment(cost [][]float64) ([]int, float64) {
+	rows := len(cost)
+	if rows == 0 {
+		return []int{}, 0
+	}
+	cols := len(cost[0])
+
+	// The algorithm needs rows <= cols, so work on the transpose otherwise.
+	transposed := rows > cols
+	a := cost
+	if transposed {
+		a = make([][]float64, cols)
+		for j := range a {
+			a[j] = make([]float64, rows)
+			for i := range rows {
+				a[j][i] = cost[i][j]
+			}
+		}
+		rows, cols = cols, rows
+	}
+
+	p, _, _ := hungarian(a)
+
+	total := 0.0
+	if transposed {
+		// p maps original columns (now rows) onto original rows (now columns).
+		assigned := make([]int, cols)
+		for i := range assigned {
+			assigned[i] = -1
+		}
+		for j := 1; j <= cols; j++ {
+			if p[j] != 0 {
+				assigned[j-1] = p[j] - 1
+				total += cost[j-1][p[j]-1]
+			}
+		}
+		return assigned, total
+	}
+
+	assigned := make([]int, rows)
+	for i := range assigned {
+		assigned[i] = -1
+	}
+	for j := 1; j <= cols; j++ {
+		if p[j] != 0 {
+			assigned[p[j]-1] = j - 1
+			total += cost[p[j]-1][j-1]
+		}
+	}
+	return assigned, total
+}
+
+// hungarian runs the Hungarian algorithm on a matrix with rows <= cols. It returns, 1-based,
+// the row assigned to each column (0 for none) and the row and column potentials u and v,
+// which satisfy u[i] + v[j] <= a[i-1][j-1] with equality on every assigned pair.
+func hungarian(a [][]float64) (p []int, u, v []float64) {
+	rows, cols := len(a), len(a[0])
+	u = make([]float64, rows+1)
+	v = make([]float64, cols+1)
+	p = make([]int, cols+1)
+	way := make([]int, cols+1)
+
+	for i := 1; i <= rows; i++ {
+		p[0] = i
+		j0 := 0
+		minv := make([]float64, cols+1)
+		used := make([]bool, cols+1)
+		for j := range minv {
+			minv[j] = math.Inf(1)
+		}
+
+		for p[j0] != 0 {
+			used[j0] = true
+			i0 := p[j0]
+			delta := math.Inf(1)
+			j1 := 0
+			for j := 1; j <= cols; j++ {
+				if used[j] {
+					continue
+				}
+				cur := a[i0-1][j-1] - u[i0] - v[j]
+				if cur < minv[j] {
+					minv[j] = cur
+					way[j] = j0
+				}
+				if minv[j] < delta {
+					delta = minv[j]
+					j1 = j
+				}
+			}
+			for j := 0; j <= cols; j++ {
+				if used[j] {
+					u[p[j]] += delta
+					v[j] -= delta
+				} else {
+					minv[j] -= delta
+				}
+			}
+			j0 = j1
+		}
+
+		for j0 != 0 {
+			j1 := way[j0]
+			p[j0] = p[j1]
+			j0 = j1
+		}
+	}
+
+	return p, u, v
+}
+
+// forcedAssignment finds a minimum-cost assignment like solveAssignment and reports for
+// each row whether its pair is part of every minimum-cost assignment. The matrix is padded
+// to a square with zero-cost dummy rows or columns, so that an assignment is optimal exactly
+// when it only uses pairs whose reduced cost under the final potentials is zero. A row can
+// then swap to another optimal pair only if it lies on an alternating cycle of such pairs,
+// i.e. in a strongly connected component of more than one row.
+func forcedAssignment(cost [][]float64) ([]int, []bool) {
+	rows := len(cost)
+	if rows == 0 {
+		return []int{}, []bool{}
+	}
+	cols := len(cost[0])
+
+	n := max(rows, cols)
+	a := make([][]float64, n)
+	for i := range a {
+		a[i] = make([]float64, n)
+		if i < rows {
+			copy(a[i], cost[i])
+		}
+	}
+	p, u, v := hungarian(a)
+
+	rowOf := make([]int, n) // Row holding each column
+	colOf := make([]int, n) // Column held by each row
+	for j := 1; j <= n; j++ {
+		rowOf[j-1] = p[j] - 1
+		colOf[p[j]-1] = j - 1
+	}
+
+	// Row i points to the row holding a column it could take at no extra cost
+	next := make([][]int, n)
+	for i := range n {
+		for j := range n {
+			if j != colOf[i] && math.Abs(a[i][j]-u[i+1]-v[j+1]) < costEpsilon {
+				next[i] = append(next[i], rowOf[j])
+			}
+		}
+	}
+
+	component := stronglyConnected(next)
+	size := make(map[int]int)
+	for _, c := range component {
+		size[c]++
+	}
+
+	assigned := make([]int, rows)
+	forced := make([]bool, rows)
+	for i := range rows {
+		assigned[i] = -1
+		if colOf[i] < cols {
+			assigned[i] = colOf[i]
+		}
+		forced[i] = size[component[i]] == 1
+	}
+	return assigned, forced
+}
+
+// stronglyConnected labels each node of a directed graph with its strongly connected
+// component, using Tarjan's algorithm
+func stronglyConnected(next [][]int) []int {
+	n := len(next)
+	index := make([]int, n)
+	low := make([]int, n)
+	onStack := make([]bool, n)
+	component := make([]int, n)
+	for i := range index {
+		index[i] = -1
+	}
+
+	stack := make([]int, 0, n)
+	counter, components := 0, 0
+	var visit func(i int)
+	visit = func(i int) {
+		index[i], low[i] = counter, counter
+		counter++
+		stack = append(stack, i)
+		onStack[i] = true
+
+		for _, j := range next[i] {
+			if index[j] < 0 {
+				visit(j)
+				low[i] = min(low[i], low[j])
+			} else if onStack[j] {
+				low[i] = min(low[i], index[j])
+			}
+		}
+
+		if low[i] == index[i] {
+			for {
+				j := stack[len(stack)-1]
+				stack = stack[:len(stack)-1]
+				onStack[j] = false
+				component[j] = components
+				if j == i {
+					break
+				}
+			}
+			components++
+		}
+	}
+
+	for i := range n {
+		if index[i] < 0 {
+			visit(i)
+		}
+	}
+	return component
+}
@@ -0,0 +1,174 @@
+package matcher
+
+import (
+	"math"
+	"time"
+
+	"github.com/farhaan/amartha-reconcile-system/internal/domain/transaction"
+)
+
+const (
+	// assignmentResolvedConfidence is the confidence of a pair picked out of an ambiguous group.
+	assignmentResolvedConfidence = 90.0
+
+	// sourceMismatchCost penalises pairing a system transaction with another bank's statement line.
+	sourceMismatchCost = 10.0
+
+	// forbiddenCost marks pairs that must never be matched (e.g. different amounts sharing a key).
+	forbiddenCost = 1e9
+
+	// costEpsilon is how close two assignment costs must be to count as a tie.
+	costEpsilon = 1e-6
+)
+
+// AssignmentMatcher matches transactions by date, type, and amount like ExactMatcher,
+// but resolves groups of identical candidates with a minimum-cost assignment instead
+// of marking the whole group unmatched.
+type AssignmentMatcher struct {
+	config MatcherConfig
+}
+
+func NewAssignmentMatcher(config MatcherConfig) TransactionMatcher {
+	return &AssignmentMatcher{
+		config: config,
+	}
+}
+
+func (am *AssignmentMatcher) SetConfig(config MatcherConfig) {
+	am.config = config
+}
+
+func (am *AssignmentMatcher) Name() string {
+	return "assignment"
+}
+
+// Match groups system and bank transactions by date_type_amount. Groups with one
+// transaction on each side match directly. Larger groups are solved as a bipartite
+// assignment scored on source bank and time of day, and a pair is only kept if it
+// appears in every optimal assignment, so genuinely tied groups still go to review.
+func (am *AssignmentMatcher) Match(systemTxns, bankTxns []*transaction.Transaction) (*MatchResult, error) {
+	result := NewMatchResult(am.Name())
+
+	keys := make([]string, 0)
+	sysGroups := make(map[string][]*transaction.Transaction)
+	for _, sysTxn := range systemTxns {
+		key := dateTypeKey(sysTxn) + "_" + formatAmount(sysTxn.AbsAmount()) + sourceKey(sysTxn, am.config)
+		if _, exists := sysGroups[key]; !exists {
+			keys = append(keys, key)
+		}
+		sysGroups[key] = append(sysGroups[key], sysTxn)
+	}
+
+	bankGroups := make(map[string][]*transaction.Transaction)
+	for _, bankTxn := range bankTxns {
+		key := dateTypeKey(bankTxn) + "_" + formatAmount(bankTxn.AbsAmount()) + sourceKey(bankTxn, am.config)
+		bankGroups[key] = append(bankGroups[key], bankTxn)
+	}
+
+	matchedSysTxns := make(map[*transaction.Transaction]bool)
+	matchedBankTxns := make(map[*transaction.Transaction]bool)
+
+	for _, key := range keys {
+		sysGroup := sysGroups[key]
+		bankGroup := bankGroups[key]
+		if len(bankGroup) == 0 {
+			continue
+		}
+
+		for _, pair := range am.matchGroup(sysGroup, bankGroup) {
+			result.Matched = append(result.Matched, pair)
+			matchedSysTxns[pair.SystemTransaction] = true
+			matchedBankTxns[pair.BankTransaction] = true
+		}
+	}
+
+	for _, sysTxn := range systemTxns {
+		if !matchedSysTxns[sysTxn] {
+			result.UnmatchedSystem = append(result.UnmatchedSystem, sysTxn)
+		}
+	}
+
+	for _, bankTxn := range bankTxns {
+		if !matchedBankTxns[bankTxn] {
+			result.UnmatchedBank = append(result.UnmatchedBank, bankTxn)
+		}
+	}
+
+	if am.config.FXRates != nil {
+		matchForeignCurrency(result, am.config)
+	}
+
+	if am.config.MatchSource {
+		result.FlagWrongBank()
+	}
+
+	result.Finalize()
+	return result, nil
+}
+
+// matchGroup solves one date_type_amount group and returns the pairs that are
+// part of every optimal assignment.
+func (am *AssignmentMatcher) matchGroup(sysGroup, bankGroup []*transaction.Transaction) []MatchPair {
+	cost := make([][]float64, len(sysGroup))
+	for i, sysTxn := range sysGroup {
+		cost[i] = make([]float64, len(bankGroup))
+		for j, bankTxn := range bankGroup {
+			cost[i][j] = am.pairCost(sysTxn, bankTxn)
+		}
+	}
+
+	assigned, forced := forcedAssignment(cost)
+	ambiguousGroup := len(sysGroup) > 1 || len(bankGroup) > 1
+
+	pairs := make([]MatchPair, 0)
+	for i, j := range assigned {
+		if j < 0 || cost[i][j] >= forbiddenCost {
+			continue
+		}
+
+		confidence := 100.0
+		if ambiguousGroup {
+			if !forced[i] {
+				continue
+			}
+			confidence = assignmentResolvedConfidence
+		}
+
+		pairs = append(pairs, MatchPair{
+			SystemTransaction: sysGroup[i],
+			BankTransaction:   bankGroup[j],
+			ConfidenceScore:   confidence,
+			AmountDiscrepancy: amountDifference(sysGroup[i].AbsAmount(), bankGroup[j].AbsAmount()),
+		})
+	}
+	return pairs
+}
+
+// pairCost scores how unlikely a pairing is using signals outside the group key:
+// a different source bank, and the gap in time of day when the bank line carries one.
+func (am *AssignmentMatcher) pairCost(sysTxn, bankTxn *transaction.Transaction) float64 {
+	if sysTxn.IsDebit() != bankTxn.IsDebit() || !amountsEqual(sysTxn.AbsAmount(), bankTxn.AbsAmount()) {
+		return forbiddenCost
+	}
+
+	cost := 0.0
+	if sysTxn.Source != bankTxn.Source {
+		cost += sourceMismatchCost
+	}
+	if hasTimeOfDay(sysTxn.TransactionDate) && hasTimeOfDay(bankTxn.TransactionDate) {
+		// Scaled to a fraction of a day so it never outweighs a source mismatch.
+		cost += math.Abs(timeOfDay(sysTxn.TransactionDate).Hours()-timeOfDay(bankTxn.TransactionDate).Hours()) / 24.0
+	}
+	return cost
+}
+
+// hasTimeOfDay reports whether a timestamp carries a clock time rather than just a date.
+func hasTimeOfDay(t time.Time) bool {
+	return timeOfDay(t) != 0
+}
+
+// timeOfDay returns the time elapsed since midnight.
+func timeOfDay(t time.Time) time.Duration {
+	h, m, s := t.Clock()
+	return time.Duration(h)*time.Hour + time.Duration(m)*time.Minute + time.Duration(s)*time.Second
+}
@@ -0,0 +1,298 @@
+package matcher
+
+import (
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/farhaan/amartha-reconcile-system/internal/domain"
+	"github.com/farhaan/amartha-reconcile-system/internal/domain/transaction"
+)
+
+func TestAssignmentMatcher_Name(t *testing.T) {
+	matcher := NewAssignmentMatcher(DefaultConfig())
+	if matcher.Name() != "assignment" {
+		t.Errorf("Expected name 'assignment', got %s", matcher.Name())
+	}
+}
+
+func TestAssignmentMatcher_Match_ResolvesBySource(t *testing.T) {
+	matcher := NewAssignmentMatcher(DefaultConfig())
+	date := time.Date(2024, 3, 15, 10, 30, 0, 0, time.UTC)
+	bankDate := time.Date(2024, 3, 15, 0, 0, 0, 0, time.UTC)
+
+	// Same date/type/amount on both sides, only the source bank tells them apart
+	systemTxns := []*transaction.Transaction{
+		createSystemTransaction("SYS001", "BCA", 150.50, domain.TransactionTypeDebit, date),
+		createSystemTransaction("SYS002", "MANDIRI", 150.50, domain.TransactionTypeDebit, date),
+	}
+
+	bankTxns := []*transaction.Transaction{
+		createBankTransaction("BANK001", "MANDIRI", -150.50, domain.TransactionTypeDebit, bankDate),
+		createBankTransaction("BANK002", "BCA", -150.50, domain.TransactionTypeDebit, bankDate),
+	}
+
+	result, err := matcher.Match(systemTxns, bankTxns)
+	if err != nil {
+		t.Fatalf("Match failed: %v", err)
+	}
+
+	if len(result.Matched) != 2 {
+		t.Fatalf("Expected 2 matches, got %d", len(result.Matched))
+	}
+
+	for _, match := range result.Matched {
+		if match.SystemTransaction.Source != match.BankTransaction.Source {
+			t.Errorf("Expected same-source pairing, got %s ↔ %s",
+				match.SystemTransaction.ID, match.BankTransaction.ID)
+		}
+		if match.ConfidenceScore != assignmentResolvedConfidence {
+			t.Errorf("Expected confidence %.0f for resolved group, got %f",
+				assignmentResolvedConfidence, match.ConfidenceScore)
+		}
+	}
+}
+
+func TestAssignmentMatcher_Match_ResolvesByTimeOfDay(t *testing.T) {
+	matcher := NewAssignmentMatcher(DefaultConfig())
+	morning := time.Date(2024, 3, 15, 9, 0, 0, 0, time.UTC)
+	evening := time.Date(2024, 3, 15, 18, 0, 0, 0, time.UTC)
+
+	systemTxns := []*transaction.Transaction{
+		createSystemTransaction("SYS001", "BCA", 150.50, domain.TransactionTypeDebit, morning),
+		createSystemTransaction("SYS002", "BCA", 150.50, domain.TransactionTypeDebit, evening),
+	}
+
+	bankTxns := []*transaction.Transaction{
+		createBankTransaction("BANK001", "BCA", -150.50, domain.TransactionTypeDebit, evening.Add(5*time.Minute)),
+		createBankTransaction("BANK002", "BCA", -150.50, domain.TransactionTypeDebit, morning.Add(5*time.Minute)),
+	}
+
+	result, err := matcher.Match(systemTxns, bankTxns)
+	if err != nil {
+		t.Fatalf("Match failed: %v", err)
+	}
+
+	if len(result.Matched) != 2 {
+		t.Fatalf("Expected 2 matches, got %d", len(result.Matched))
+	}
+
+	expected := map[string]string{"SYS001": "BANK002", "SYS002": "BANK001"}
+	for _, match := range result.Matched {
+		if expected[match.SystemTransaction.ID] != match.BankTransaction.ID {
+			t.Errorf("Expected %s ↔ %s, got %s",
+				match.SystemTransaction.ID, expected[match.SystemTransaction.ID], match.BankTransaction.ID)
+		}
+	}
+}
+
+func TestAssignmentMatcher_Match_TiedGroupStaysUnmatched(t *testing.T) {
+	matcher := NewAssignmentMatcher(DefaultConfig())
+	date := time.Date(2024, 3, 15, 0, 0, 0, 0, time.UTC)
+
+	// Nothing tells the two bank lines apart
+	systemTxns := []*transaction.Transaction{
+		createSystemTransaction("SYS001", "BCA", 150.50, domain.TransactionTypeDebit, date),
+		createSystemTransaction("SYS002", "BCA", 150.50, domain.TransactionTypeDebit, date),
+	}
+
+	bankTxns := []*transaction.Transaction{
+		createBankTransaction("BANK001", "BCA", -150.50, domain.TransactionTypeDebit, date),
+		createBankTransaction("BANK002", "BCA", -150.50, domain.TransactionTypeDebit, date),
+	}
+
+	result, err := matcher.Match(systemTxns, bankTxns)
+	if err != nil {
+		t.Fatalf("Match failed: %v", err)
+	}
+
+	if len(result.Matched) != 0 {
+		t.Errorf("Expected 0 matches (tied), got %d", len(result.Matched))
+	}
+
+	if len(result.UnmatchedSystem) != 2 || len(result.UnmatchedBank) != 2 {
+		t.Errorf("Expected 2 unmatched on each side, got %d system and %d bank",
+			len(result.UnmatchedSystem), len(result.UnmatchedBank))
+	}
+}
+
+func TestAssignmentMatcher_Match_LargeGroup(t *testing.T) {
+	matcher := NewAssignmentMatcher(DefaultConfig())
+	systemTxns, bankTxns := sameAmountGroup(200)
+
+	// Two bank lines at the same time cannot be told apart, the rest still match
+	tied := bankTxns[10].TransactionDate
+	systemTxns[11].TransactionDate = systemTxns[10].TransactionDate
+	bankTxns[11].TransactionDate = tied
+
+	result, err := matcher.Match(systemTxns, bankTxns)
+	if err != nil {
+		t.Fatalf("Match failed: %v", err)
+	}
+
+	if len(result.Matched) != len(systemTxns)-2 {
+		t.Errorf("Expected %d matches, got %d", len(systemTxns)-2, len(result.Matched))
+	}
+	for _, pair := range result.Matched {
+		if pair.SystemTransaction.ID[3:] != pair.BankTransaction.ID[4:] {
+			t.Errorf("Expected %s matched to its own bank line, got %s", pair.SystemTransaction.ID, pair.BankTransaction.ID)
+		}
+	}
+	if len(result.UnmatchedSystem) != 2 || len(result.UnmatchedBank) != 2 {
+		t.Errorf("Expected the tied pair unmatched, got %d system and %d bank",
+			len(result.UnmatchedSystem), len(result.UnmatchedBank))
+	}
+}
+
+func TestForcedAssignment(t *testing.T) {
+	tests := []struct {
+		name     string
+		cost     [][]float64
+		assigned []int
+		forced   []bool
+	}{
+		{"unique", [][]float64{{0, 1}, {1, 0}}, []int{0, 1}, []bool{true, true}},
+		{"tied", [][]float64{{0, 0}, {0, 0}}, nil, []bool{false, false}},
+		{"tie on one row", [][]float64{{0, 0, 5}, {0, 0, 5}, {5, 5, 0}}, nil, []bool{false, false, true}},
+		// The spare column costs the same for row 0, so it is not forced
+		{"spare column", [][]float64{{0, 0}}, nil, []bool{false}},
+		{"spare row", [][]float64{{0}, {1}}, []int{0, -1}, []bool{true, true}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assigned, forced := forcedAssignment(tt.cost)
+			for i := range tt.forced {
+				if tt.assigned != nil && assigned[i] != tt.assigned[i] {
+					t.Errorf("Row %d: expected column %d, got %d", i, tt.assigned[i], assigned[i])
+				}
+				if forced[i] != tt.forced[i] {
+					t.Errorf("Row %d: expected forced %v, got %v", i, tt.forced[i], forced[i])
+				}
+			}
+		})
+	}
+}
+
+func TestAssignmentMatcher_Match_UnevenGroup(t *testing.T) {
+	matcher := NewAssignmentMatcher(DefaultConfig())
+	date := time.Date(2024, 3, 15, 0, 0, 0, 0, time.UTC)
+
+	// One system transaction, two bank lines, only one from the right bank
+	systemTxns := []*transaction.Transaction{
+		createSystemTransaction("SYS001", "BCA", 150.50, domain.TransactionTypeDebit, date),
+	}
+
+	bankTxns := []*transaction.Transaction{
+		createBankTransaction("BANK001", "MANDIRI", -150.50, domain.TransactionTypeDebit, date),
+		createBankTransaction("BANK002", "BCA", -150.50, domain.TransactionTypeDebit, date),
+	}
+
+	result, err := matcher.Match(systemTxns, bankTxns)
+	if err != nil {
+		t.Fatalf("Match failed: %v", err)
+	}
+
+	if len(result.Matched) != 1 {
+		t.Fatalf("Expected 1 match, got %d", len(result.Matched))
+	}
+
+	if result.Matched[0].BankTransaction.ID != "BANK002" {
+		t.Errorf("Expected BANK002, got %s", result.Matched[0].BankTransaction.ID)
+	}
+
+	if len(result.UnmatchedBank) != 1 || result.UnmatchedBank[0].ID != "BANK001" {
+		t.Errorf("Expected BANK001 left unmatched, got %v", len(result.UnmatchedBank))
+	}
+}
+
+func TestAssignmentMatcher_Match_SinglePairIsExact(t *testing.T) {
+	matcher := NewAssignmentMatcher(DefaultConfig())
+	date := time.Date(2024, 3, 15, 0, 0, 0, 0, time.UTC)
+
+	systemTxns := []*transaction.Transaction{
+		createSystemTransaction("SYS001", "BCA", 150.50, domain.TransactionTypeDebit, date),
+	}
+
+	bankTxns := []*transaction.Transaction{
+		createBankTransaction("BANK001", "BCA", -150.50, domain.TransactionTypeDebit, date),
+	}
+
+	result, err := matcher.Match(systemTxns, bankTxns)
+	if err != nil {
+		t.Fatalf("Match failed: %v", err)
+	}
+
+	if len(result.Matched) != 1 {
+		t.Fatalf("Expected 1 match, got %d", len(result.Matched))
+	}
+
+	if result.Matched[0].ConfidenceScore != 100.0 {
+		t.Errorf("Expected confidence 100 for unambiguous pair, got %f", result.Matched[0].ConfidenceScore)
+	}
+}
+
+func TestSolveAssignment(t *testing.T) {
+	cost := [][]float64{
+		{4, 1, 3},
+		{2, 0, 5},
+		{3, 2, 2},
+	}
+
+	assigned, total := solveAssignment(cost)
+	if total != 5 {
+		t.Errorf("Expected optimal cost 5, got %f", total)
+	}
+
+	expected := []int{1, 0, 2}
+	for i, j := range assigned {
+		if j != expected[i] {
+			t.Errorf("Row %d: expected column %d, got %d", i, expected[i], j)
+		}
+	}
+}
+
+func TestSolveAssignment_MoreRowsThanColumns(t *testing.T) {
+	cost := [][]float64{
+		{5},
+		{1},
+		{3},
+	}
+
+	assigned, total := solveAssignment(cost)
+	if total != 1 {
+		t.Errorf("Expected optimal cost 1, got %f", total)
+	}
+
+	expected := []int{-1, 0, -1}
+	for i, j := range assigned {
+		if j != expected[i] {
+			t.Errorf("Row %d: expected column %d, got %d", i, expected[i], j)
+		}
+	}
+}
+
+func BenchmarkAssignmentMatcher_Match_LargeGroup(b *testing.B) {
+	matcher := NewAssignmentMatcher(DefaultConfig())
+	systemTxns, bankTxns := sameAmountGroup(200)
+
+	for b.Loop() {
+		if _, err := matcher.Match(systemTxns, bankTxns); err != nil {
+			b.Fatalf("Match failed: %v", err)
+		}
+	}
+}
+
+// sameAmountGroup returns n same-day transfers of one amount on each side, each bank
+// line a minute after its system transaction so the assignment is unique
+func sameAmountGroup(n int) ([]*transaction.Transaction, []*transaction.Transaction) {
+	date := time.Date(2024, 3, 15, 9, 0, 0, 0, time.UTC)
+	systemTxns := make([]*transaction.Transaction, 0, n)
+	bankTxns := make([]*transaction.Transaction, 0, n)
+	for i := range n {
+		at := date.Add(time.Duration(i) * 5 * time.Minute)
+		systemTxns = append(systemTxns, createSystemTransaction(fmt.Sprintf("SYS%03d", i), "BCA", 150.50, domain.TransactionTypeDebit, at))
+		bankTxns = append(bankTxns, createBankTransaction(fmt.Sprintf("BANK%03d", i), "BCA", -150.50, domain.TransactionTypeDebit, at.Add(time.Minute)))
+	}
+	return systemTxns, bankTxns
+}
@@ -0,0 +1,144 @@
+package matcher
+
+import (
+	"sort"
+	"time"
+
+	"github.com/farhaan/amartha-reconcile-system/internal/domain"
+	"github.com/farhaan/amartha-reconcile-system/internal/domain/transaction"
+)
+
+// Breakdown holds the statistics of the transactions that share one key, e.g. a bank,
+// a transaction type or a day. Each transaction counts under its own key, so the two
+// sides of a pair can fall under different days.
+type Breakdown struct {
+	Key           string
+	SystemTxns    int
+	BankTxns      int
+	MatchedSystem int     // System transactions that are part of a pair or group
+	MatchedBank   int     // Bank transactions that are part of a pair or group
+	MatchRate     float64 // Matched transactions of both sides as a percentage of all
+
+	// UnmatchedSystemAmount and UnmatchedBankAmount sum the absolute amounts left unmatched, in BaseCurrency
+	UnmatchedSystemAmount domain.Money
+	UnmatchedBankAmount   domain.Money
+
+	// NetDifference is the signed sum of system minus bank amounts, in BaseCurrency
+	NetDifference domain.Money
+
+	// UnconvertedAmount holds unmatched foreign currency amounts that could not be converted to BaseCurrency
+	UnconvertedAmount map[domain.Currency]domain.Money
+}
+
+// UnmatchedSystem returns the number of system transactions left unmatched
+func (b Breakdown) UnmatchedSystem() int {
+	return b.SystemTxns - b.MatchedSystem
+}
+
+// UnmatchedBank returns the number of bank transactions left unmatched
+func (b Breakdown) UnmatchedBank() int {
+	return b.BankTxns - b.MatchedBank
+}
+
+// breakdown computes one Breakdown per key the transactions of the result map to, sorted by key
+func (mr *MatchResult) breakdown(key func(*transaction.Transaction) string) []Breakdown {
+	rows := make(map[string]*Breakdown)
+	row := func(txn *transaction.Transaction) *Breakdown {
+		k := key(txn)
+		if rows[k] == nil {
+			rows[k] = &Breakdown{
+				Key:                   k,
+				UnmatchedSystemAmount: domain.NewMoney(0, mr.BaseCurrency),
+				UnmatchedBankAmount:   domain.NewMoney(0, mr.BaseCurrency),
+				NetDifference:         domain.NewMoney(0, mr.BaseCurrency),
+				UnconvertedAmount:     make(map[domain.Currency]domain.Money),
+			}
+		}
+		return rows[k]
+	}
+	addNet := func(txn *transaction.Transaction) {
+		amount, ok := mr.toBaseCurrency(txn.Amount, txn.TransactionDate)
+		if !ok {
+			return
+		}
+		if txn.SourceType == domain.SourceTypeBank {
+			amount = amount.Neg()
+		}
+		row(txn).NetDifference = row(txn).NetDifference.Add(amount)
+	}
+	addUnmatched := func(b *Breakdown, total *domain.Money, txn *transaction.Transaction) {
+		if converted, ok := mr.toBaseCurrency(txn.AbsAmount(), txn.TransactionDate); ok {
+			*total = total.Add(converted)
+			return
+		}
+		b.UnconvertedAmount[txn.Amount.Currency] = b.UnconvertedAmount[txn.Amount.Currency].Add(txn.AbsAmount())
+	}
+
+	for _, pair := range mr.Matched {
+		row(pair.SystemTransaction).SystemTxns++
+		row(pair.SystemTransaction).MatchedSystem++
+		row(pair.BankTransaction).BankTxns++
+		row(pair.BankTransaction).MatchedBank++
+		addNet(pair.SystemTransaction)
+		addNet(pair.BankTransaction)
+	}
+	for _, group := range mr.Grouped {
+		for _, txn := range group.SystemTransactions {
+			row(txn).SystemTxns++
+			row(txn).MatchedSystem++
+			addNet(txn)
+		}
+		for _, txn := range group.BankTransactions {
+			row(txn).BankTxns++
+			row(txn).MatchedBank++
+			addNet(txn)
+		}
+	}
+	for _, txn := range mr.UnmatchedSystem {
+		b := row(txn)
+		b.SystemTxns++
+		addUnmatched(b, &b.UnmatchedSystemAmount, txn)
+		addNet(txn)
+	}
+	for _, txn := range mr.UnmatchedBank {
+		b := row(txn)
+		b.BankTxns++
+		addUnmatched(b, &b.UnmatchedBankAmount, txn)
+		addNet(txn)
+	}
+
+	sorted := make([]Breakdown, 0, len(rows))
+	for _, b := range rows {
+		total := b.SystemTxns + b.BankTxns
+		b.MatchRate = float64(b.MatchedSystem+b.MatchedBank) / float64(total) * 100.0
+		sorted = append(sorted, *b)
+	}
+	sort.Slice(sorted, func(i, j int) bool { return sorted[i].Key < sorted[j].Key })
+	return sorted
+}
+
+// toBaseCurrency converts amount to BaseCurrency with the FX rates of the given day,
+// reporting false when there is no rate for it
+func (mr *MatchResult) toBaseCurrency(amount domain.Money, date time.Time) (domain.Money, bool) {
+	if amount.Currency == "" || amount.Currency == mr.BaseCurrency {
+		return amount, true
+	}
+	if mr.fxRates != nil {
+		if converted, err := mr.fxRates.Convert(amount, mr.BaseCurrency, date); err == nil {
+			return converted, true
+		}
+	}
+	return domain.Money{}, false
+}
+
+func breakdownBySource(txn *transaction.Transaction) string {
+	return txn.Source
+}
+
+func breakdownByType(txn *transaction.Transaction) string {
+	return string(txn.Type)
+}
+
+func breakdownByDate(txn *transaction.Transaction) string {
+	return txn.TransactionDate.Format("2006-01-02")
+}
@@ -0,0 +1,103 @@
+package matcher
+
+import (
+	"testing"
+	"time"
+
+	"github.com/farhaan/amartha-reconcile-system/internal/domain"
+	"github.com/farhaan/amartha-reconcile-system/internal/domain/transaction"
+)
+
+func TestMatchResult_Breakdowns(t *testing.T) {
+	day1 := time.Date(2024, 3, 15, 0, 0, 0, 0, time.UTC)
+	day2 := day1.AddDate(0, 0, 1)
+	matcher := NewExactMatcher(DefaultConfig())
+
+	systemTxns := []*transaction.Transaction{
+		createSystemTransaction("SYS001", "BCA", 100.00, domain.TransactionTypeCredit, day1),
+		createSystemTransaction("SYS002", "BCA", 50.00, domain.TransactionTypeDebit, day1),
+		createSystemTransaction("SYS003", "MANDIRI", 75.00, domain.TransactionTypeCredit, day2),
+	}
+
+	bankTxns := []*transaction.Transaction{
+		createBankTransaction("BANK001", "BCA", 100.00, domain.TransactionTypeCredit, day1),
+		createBankTransaction("BANK002", "MANDIRI", -20.00, domain.TransactionTypeDebit, day2),
+		createForeignBankTransaction("BANK003", "MANDIRI", 1000, domain.CurrencyUSD, domain.TransactionTypeCredit, day2),
+	}
+
+	result, err := matcher.Match(systemTxns, bankTxns)
+	if err != nil {
+		t.Fatalf("Match failed: %v", err)
+	}
+
+	tests := []struct {
+		name       string
+		breakdowns []Breakdown
+		key        string
+		system     int
+		bank       int
+		matched    int
+		rate       float64
+		unmatchedS domain.Money
+		unmatchedB domain.Money
+	}{
+		{"source BCA", result.BySource, "BCA", 2, 1, 2, 66.7, money(50.00), money(0)},
+		{"source MANDIRI", result.BySource, "MANDIRI", 1, 2, 0, 0, money(75.00), money(20.00)},
+		{"type CREDIT", result.ByType, "CREDIT", 2, 2, 2, 50, money(75.00), money(0)},
+		{"type DEBIT", result.ByType, "DEBIT", 1, 1, 0, 0, money(50.00), money(20.00)},
+		{"date 2024-03-15", result.ByDate, "2024-03-15", 2, 1, 2, 66.7, money(50.00), money(0)},
+		{"date 2024-03-16", result.ByDate, "2024-03-16", 1, 2, 0, 0, money(75.00), money(20.00)},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if len(tt.breakdowns) != 2 {
+				t.Fatalf("Expected 2 keys, got %d", len(tt.breakdowns))
+			}
+
+			var b *Breakdown
+			for i := range tt.breakdowns {
+				if tt.breakdowns[i].Key == tt.key {
+					b = &tt.breakdowns[i]
+				}
+			}
+			if b == nil {
+				t.Fatalf("Expected a breakdown for %s", tt.key)
+			}
+
+			if b.SystemTxns != tt.system || b.BankTxns != tt.bank {
+				t.Errorf("Expected %d system and %d bank transactions, got %d and %d", tt.system, tt.bank, b.SystemTxns, b.BankTxns)
+			}
+			if b.MatchedSystem+b.MatchedBank != tt.matched {
+				t.Errorf("Expected %d matched transactions, got %d", tt.matched, b.MatchedSystem+b.MatchedBank)
+			}
+			if b.MatchRate < tt.rate-0.05 || b.MatchRate > tt.rate+0.05 {
+				t.Errorf("Expected match rate %.1f%%, got %.1f%%", tt.rate, b.MatchRate)
+			}
+			if !b.UnmatchedSystemAmount.Equal(tt.unmatchedS) {
+				t.Errorf("Expected unmatched system amount %s, got %s", tt.unmatchedS, b.UnmatchedSystemAmount)
+			}
+			if !b.UnmatchedBankAmount.Equal(tt.unmatchedB) {
+				t.Errorf("Expected unmatched bank amount %s, got %s", tt.unmatchedB, b.UnmatchedBankAmount)
+			}
+		})
+	}
+
+	// The USD line has no rate to IDR, so it is kept apart rather than added in
+	mandiri := result.BySource[1]
+	if usd := mandiri.UnconvertedAmount[domain.CurrencyUSD]; usd.String() != "10.00" {
+		t.Errorf("Expected 10.00 USD unconverted for MANDIRI, got %s", usd)
+	}
+	if mandiri.UnmatchedSystem() != 1 || mandiri.UnmatchedBank() != 2 {
+		t.Errorf("Expected 1 system and 2 bank transactions unmatched, got %d and %d", mandiri.UnmatchedSystem(), mandiri.UnmatchedBank())
+	}
+}
+
+func TestMatchResult_Breakdowns_Empty(t *testing.T) {
+	result := NewMatchResult("exact")
+	result.Finalize()
+
+	if len(result.BySource) != 0 || len(result.ByType) != 0 || len(result.ByDate) != 0 {
+		t.Errorf("Expected no breakdowns for an empty result, got %d, %d and %d", len(result.BySource), len(result.ByType), len(result.ByDate))
+	}
+}
@@ -0,0 +1,145 @@
+package matcher
+
+import (
+	"time"
+
+	"github.com/farhaan/amartha-reconcile-system/internal/domain/transaction"
+)
+
+// dateWindowPenaltyPerDay is how much confidence a pair loses for each day between its dates.
+const dateWindowPenaltyPerDay = 5.0
+
+// DateWindowMatcher matches transactions by type and amount when their dates
+// fall within MatcherConfig.DateWindowDays of each other.
+type DateWindowMatcher struct {
+	config MatcherConfig
+}
+
+func NewDateWindowMatcher(config MatcherConfig) TransactionMatcher {
+	return &DateWindowMatcher{
+		config: config,
+	}
+}
+
+func (dm *DateWindowMatcher) SetConfig(config MatcherConfig) {
+	dm.config = config
+}
+
+func (dm *DateWindowMatcher) Name() string {
+	return "date_window"
+}
+
+// Match finds matching transactions whose dates are up to DateWindowDays apart.
+// Bank transactions are indexed by type_amount, then matching runs in passes of
+// increasing day offset so every system transaction gets its closest bank date first.
+// If a pass finds more than one candidate at the same offset, the system transaction
+// is ambiguous and stays unmatched rather than guessing.
+func (dm *DateWindowMatcher) Match(systemTxns, bankTxns []*transaction.Transaction) (*MatchResult, error) {
+	result := NewMatchResult(dm.Name())
+
+	window := dm.config.DateWindowDays
+	if window < 0 {
+		window = 0
+	}
+
+	bankTxnMap := make(map[string][]*transaction.Transaction)
+	for _, bankTxn := range bankTxns {
+		key := typeAmountKey(bankTxn) + sourceKey(bankTxn, dm.config)
+		bankTxnMap[key] = append(bankTxnMap[key], bankTxn)
+	}
+
+	matchedBankTxns := make(map[string]bool)
+	matchedSysTxns := make(map[*transaction.Transaction]bool)
+	ambiguousSysTxns := make(map[*transaction.Transaction]bool)
+
+	for offset := 0; offset <= window; offset++ {
+		for _, sysTxn := range systemTxns {
+			if matchedSysTxns[sysTxn] || ambiguousSysTxns[sysTxn] {
+				continue
+			}
+
+			var found *transaction.Transaction
+			count := 0
+			for _, bankTxn := range bankTxnMap[typeAmountKey(sysTxn)+sourceKey(sysTxn, dm.config)] {
+				if matchedBankTxns[bankTxn.ID] {
+					continue
+				}
+				if sysTxn.IsDebit() != bankTxn.IsDebit() || !amountsEqual(sysTxn.AbsAmount(), bankTxn.AbsAmount()) {
+					continue
+				}
+				if absInt(dayOffset(sysTxn.TransactionDate, bankTxn.TransactionDate)) != offset {
+					continue
+				}
+				found = bankTxn
+				count++
+			}
+
+			if count > 1 {
+				ambiguousSysTxns[sysTxn] = true
+				continue
+			}
+			if count == 0 {
+				continue
+			}
+
+			days := dayOffset(sysTxn.TransactionDate, found.TransactionDate)
+			pair := MatchPair{
+				SystemTransaction: sysTxn,
+				BankTransaction:   found,
+				ConfidenceScore:   100.0 - float64(absInt(days))*dateWindowPenaltyPerDay,
+				DayOffset:         days,
+			}
+			result.Matched = append(result.Matched, pair)
+			matchedBankTxns[found.ID] = true
+			matchedSysTxns[sysTxn] = true
+		}
+	}
+
+	for _, sysTxn := range systemTxns {
+		if !matchedSysTxns[sysTxn] {
+			result.UnmatchedSystem = append(result.UnmatchedSystem, sysTxn)
+		}
+	}
+
+	for _, bankTxn := range bankTxns {
+		if !matchedBankTxns[bankTxn.ID] {
+			result.UnmatchedBank = append(result.UnmatchedBank, bankTxn)
+		}
+	}
+
+	if dm.config.FXRates != nil {
+		matchForeignCurrency(result, dm.config)
+	}
+
+	if dm.config.MatchSource {
+		result.FlagWrongBank()
+	}
+
+	result.Finalize()
+	return result, nil
+}
+
+// typeAmountKey creates a key like "debit_IDR15050" for indexing without the date.
+func typeAmountKey(txn *transaction.Transaction) string {
+	typeStr := "credit"
+	if txn.IsDebit() {
+		typeStr = "debit"
+	}
+	return typeStr + "_" + formatAmount(txn.AbsAmount())
+}
+
+// dayOffset returns the number of calendar days from sysDate to bankDate (ignores time).
+func dayOffset(sysDate, bankDate time.Time) int {
+	y1, m1, d1 := sysDate.Date()
+	y2, m2, d2 := bankDate.Date()
+	from := time.Date(y1, m1, d1, 0, 0, 0, 0, time.UTC)
+	to := time.Date(y2, m2, d2, 0, 0, 0, 0, time.UTC)
+	return int(to.Sub(from).Hours() / 24)
+}
+
+func absInt(n int) int {
+	if n < 0 {
+		return -n
+	}
+	return n
+}
@@ -0,0 +1,164 @@
+package matcher
+
+import (
+	"testing"
+	"time"
+
+	"github.com/farhaan/amartha-reconcile-system/internal/domain"
+	"github.com/farhaan/amartha-reconcile-system/internal/domain/transaction"
+)
+
+func TestDateWindowMatcher_Name(t *testing.T) {
+	matcher := NewDateWindowMatcher(DefaultConfig())
+	if matcher.Name() != "date_window" {
+		t.Errorf("Expected name 'date_window', got %s", matcher.Name())
+	}
+}
+
+func TestDateWindowMatcher_Match_WithinWindow(t *testing.T) {
+	matcher := NewDateWindowMatcher(MatcherConfig{DateWindowDays: 3})
+	sysDate := time.Date(2024, 3, 15, 10, 30, 0, 0, time.UTC)
+	bankDate := time.Date(2024, 3, 17, 0, 0, 0, 0, time.UTC)
+
+	systemTxns := []*transaction.Transaction{
+		createSystemTransaction("SYS001", "BCA", 150.50, domain.TransactionTypeDebit, sysDate),
+	}
+
+	bankTxns := []*transaction.Transaction{
+		createBankTransaction("BANK001", "BCA", -150.50, domain.TransactionTypeDebit, bankDate),
+	}
+
+	result, err := matcher.Match(systemTxns, bankTxns)
+	if err != nil {
+		t.Fatalf("Match failed: %v", err)
+	}
+
+	if len(result.Matched) != 1 {
+		t.Fatalf("Expected 1 match, got %d", len(result.Matched))
+	}
+
+	if result.Matched[0].DayOffset != 2 {
+		t.Errorf("Expected day offset 2, got %d", result.Matched[0].DayOffset)
+	}
+
+	if result.Matched[0].ConfidenceScore >= 100.0 {
+		t.Errorf("Expected confidence below 100 for offset match, got %f", result.Matched[0].ConfidenceScore)
+	}
+}
+
+func TestDateWindowMatcher_Match_OutsideWindow(t *testing.T) {
+	matcher := NewDateWindowMatcher(MatcherConfig{DateWindowDays: 1})
+	sysDate := time.Date(2024, 3, 15, 0, 0, 0, 0, time.UTC)
+	bankDate := time.Date(2024, 3, 17, 0, 0, 0, 0, time.UTC)
+
+	systemTxns := []*transaction.Transaction{
+		createSystemTransaction("SYS001", "BCA", 150.50, domain.TransactionTypeDebit, sysDate),
+	}
+
+	bankTxns := []*transaction.Transaction{
+		createBankTransaction("BANK001", "BCA", -150.50, domain.TransactionTypeDebit, bankDate),
+	}
+
+	result, err := matcher.Match(systemTxns, bankTxns)
+	if err != nil {
+		t.Fatalf("Match failed: %v", err)
+	}
+
+	if len(result.Matched) != 0 {
+		t.Errorf("Expected 0 matches (outside window), got %d", len(result.Matched))
+	}
+
+	if len(result.UnmatchedSystem) != 1 || len(result.UnmatchedBank) != 1 {
+		t.Errorf("Expected 1 unmatched on each side, got %d system and %d bank",
+			len(result.UnmatchedSystem), len(result.UnmatchedBank))
+	}
+}
+
+func TestDateWindowMatcher_Match_PrefersClosestDate(t *testing.T) {
+	matcher := NewDateWindowMatcher(MatcherConfig{DateWindowDays: 3})
+	date1 := time.Date(2024, 3, 15, 0, 0, 0, 0, time.UTC)
+	date2 := time.Date(2024, 3, 16, 0, 0, 0, 0, time.UTC)
+
+	// SYS001 comes first but SYS002 is the exact-date match for BANK001
+	systemTxns := []*transaction.Transaction{
+		createSystemTransaction("SYS001", "BCA", 150.50, domain.TransactionTypeDebit, date1),
+		createSystemTransaction("SYS002", "BCA", 150.50, domain.TransactionTypeDebit, date2),
+	}
+
+	bankTxns := []*transaction.Transaction{
+		createBankTransaction("BANK001", "BCA", -150.50, domain.TransactionTypeDebit, date2),
+	}
+
+	result, err := matcher.Match(systemTxns, bankTxns)
+	if err != nil {
+		t.Fatalf("Match failed: %v", err)
+	}
+
+	if len(result.Matched) != 1 {
+		t.Fatalf("Expected 1 match, got %d", len(result.Matched))
+	}
+
+	if result.Matched[0].SystemTransaction.ID != "SYS002" {
+		t.Errorf("Expected SYS002 to match on the same day, got %s", result.Matched[0].SystemTransaction.ID)
+	}
+
+	if result.Matched[0].DayOffset != 0 {
+		t.Errorf("Expected day offset 0, got %d", result.Matched[0].DayOffset)
+	}
+}
+
+func TestDateWindowMatcher_Match_TypeMismatch(t *testing.T) {
+	matcher := NewDateWindowMatcher(MatcherConfig{DateWindowDays: 3})
+	date := time.Date(2024, 3, 15, 0, 0, 0, 0, time.UTC)
+
+	systemTxns := []*transaction.Transaction{
+		createSystemTransaction("SYS001", "BCA", 150.50, domain.TransactionTypeDebit, date),
+	}
+
+	bankTxns := []*transaction.Transaction{
+		createBankTransaction("BANK001", "BCA", 150.50, domain.TransactionTypeCredit, date.AddDate(0, 0, 1)),
+	}
+
+	result, err := matcher.Match(systemTxns, bankTxns)
+	if err != nil {
+		t.Fatalf("Match failed: %v", err)
+	}
+
+	if len(result.Matched) != 0 {
+		t.Errorf("Expected 0 matches (type mismatch), got %d", len(result.Matched))
+	}
+}
+
+func TestDateWindowMatcher_Match_AmbiguousAtSameOffset(t *testing.T) {
+	matcher := NewDateWindowMatcher(MatcherConfig{DateWindowDays: 3})
+	date := time.Date(2024, 3, 15, 0, 0, 0, 0, time.UTC)
+
+	systemTxns := []*transaction.Transaction{
+		createSystemTransaction("SYS001", "BCA", 150.50, domain.TransactionTypeDebit, date),
+	}
+
+	// One day before and one day after are equally close
+	bankTxns := []*transaction.Transaction{
+		createBankTransaction("BANK001", "BCA", -150.50, domain.TransactionTypeDebit, date.AddDate(0, 0, -1)),
+		createBankTransaction("BANK002", "BCA", -150.50, domain.TransactionTypeDebit, date.AddDate(0, 0, 1)),
+	}
+
+	result, err := matcher.Match(systemTxns, bankTxns)
+	if err != nil {
+		t.Fatalf("Match failed: %v", err)
+	}
+
+	if len(result.Matched) != 0 {
+		t.Errorf("Expected 0 matches (ambiguous), got %d", len(result.Matched))
+	}
+
+	if len(result.UnmatchedBank) != 2 {
+		t.Errorf("Expected 2 unmatched bank transactions, got %d", len(result.UnmatchedBank))
+	}
+}
+
+func TestNewMatcher_UnknownName(t *testing.T) {
+	if _, err := NewMatcher("nope", DefaultConfig()); err == nil {
+		t.Error("Expected error for unknown matcher name")
+	}
+}
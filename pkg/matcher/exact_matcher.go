@@ -1,9 +1,11 @@
 package matcher
 
 import (
-	"math"
+	"strconv"
+	"strings"
 	"time"
 
+	"github.com/farhaan/amartha-reconcile-system/internal/domain"
 	"github.com/farhaan/amartha-reconcile-system/internal/domain/transaction"
 )
 
@@ -89,12 +91,21 @@ func (em *ExactMatcher) Match(systemTxns, bankTxns []*transaction.Transaction) (
 		}
 	}
 
+	if em.config.FXRates != nil {
+		matchForeignCurrency(result, em.config)
+	}
+
+	if em.config.MatchSource {
+		result.FlagWrongBank()
+	}
+
 	result.Finalize()
 	return result, nil
 }
 
-// generateKey creates a key like "2024-03-15_debit_15050" for hashing.
+// generateKey creates a key like "2024-03-15_debit_IDR15050" for hashing.
 // Uses absolute amount so debits and credits with same value get different keys.
+// With MatchSource set the source bank is appended, e.g. "2024-03-15_debit_IDR15050_BCA".
 func (em *ExactMatcher) generateKey(txn *transaction.Transaction) string {
 	dateStr := txn.TransactionDate.Format("2006-01-02")
 	typeStr := "credit"
@@ -102,11 +113,14 @@ func (em *ExactMatcher) generateKey(txn *transaction.Transaction) string {
 		typeStr = "debit"
 	}
 	amount := txn.AbsAmount()
-	return dateStr + "_" + typeStr + "_" + formatAmount(amount)
+	return dateStr + "_" + typeStr + "_" + formatAmount(amount) + sourceKey(txn, em.config)
 }
 
-// isExactMatch checks if two transactions are the same (date, type, amount).
+// isExactMatch checks if two transactions are the same (date, type, amount, and source when MatchSource is set).
 func (em *ExactMatcher) isExactMatch(sysTxn, bankTxn *transaction.Transaction) bool {
+	if em.config.MatchSource && !strings.EqualFold(sysTxn.Source, bankTxn.Source) {
+		return false
+	}
 	if !isSameDay(sysTxn.TransactionDate, bankTxn.TransactionDate) {
 		return false
 	}
@@ -120,8 +134,8 @@ func (em *ExactMatcher) isExactMatch(sysTxn, bankTxn *transaction.Transaction) b
 }
 
 // calculateDiscrepancy returns the amount difference. Should always be 0 for exact matches.
-func (em *ExactMatcher) calculateDiscrepancy(sysTxn, bankTxn *transaction.Transaction) float64 {
-	return math.Abs(sysTxn.AbsAmount() - bankTxn.AbsAmount())
+func (em *ExactMatcher) calculateDiscrepancy(sysTxn, bankTxn *transaction.Transaction) domain.Money {
+	return amountDifference(sysTxn.AbsAmount(), bankTxn.AbsAmount())
 }
 
 // isSameDay checks if two dates are on the same day (ignores time).
@@ -131,13 +145,23 @@ func isSameDay(t1, t2 time.Time) bool {
 	return y1 == y2 && m1 == m2 && d1 == d2
 }
 
-// amountsEqual checks if two amounts are equal (within 0.001 for floating point errors).
-func amountsEqual(a1, a2 float64) bool {
-	const epsilon = 0.001
-	return math.Abs(a1-a2) < epsilon
+// amountsEqual checks if two amounts are exactly equal, including currency.
+func amountsEqual(a1, a2 domain.Money) bool {
+	return a1.Equal(a2)
+}
+
+// amountDifference returns the absolute difference between two amounts.
+func amountDifference(a1, a2 domain.Money) domain.Money {
+	return a1.Sub(a2).Abs()
 }
 
-// formatAmount converts amount to string for use in keys.
-func formatAmount(amount float64) string {
-	return string(rune(int(amount * 100)))
+// formatAmount converts amount to string for use in keys, e.g. "IDR15050".
+// Writes the currency and the exact minor units in decimal, so every distinct
+// amount gets a distinct key regardless of magnitude.
+func formatAmount(amount domain.Money) string {
+	currency := amount.Currency
+	if currency == "" {
+		currency = domain.DefaultCurrency
+	}
+	return string(currency) + strconv.FormatInt(amount.Minor, 10)
 }
@@ -51,8 +51,8 @@ func TestExactMatcher_Match_ExactMatches(t *testing.T) {
 		if match.ConfidenceScore != 100.0 {
 			t.Errorf("Expected confidence score 100.0, got %f", match.ConfidenceScore)
 		}
-		if match.AmountDiscrepancy != 0 {
-			t.Errorf("Expected no discrepancy for exact match, got %f", match.AmountDiscrepancy)
+		if !match.AmountDiscrepancy.IsZero() {
+			t.Errorf("Expected no discrepancy for exact match, got %s", match.AmountDiscrepancy)
 		}
 	}
 }
@@ -348,6 +348,186 @@ func TestExactMatcher_StrictMode_UnambiguousAfterMatching(t *testing.T) {
 	}
 }
 
+// Tests for key generation with realistic IDR magnitudes
+
+func TestFormatAmount_NoCollisions(t *testing.T) {
+	amounts := []float64{
+		150.50,
+		11141.11,         // last amount that fit in the rune range
+		11141.12,         // first amount past it
+		553.60,           // minor units inside the UTF-16 surrogate range
+		553.61,           // also a surrogate, used to map to U+FFFD as well
+		2500000000.00,    // 2.5 billion rupiah
+		2500000000.01,    // one sen more
+		3000000000.00,    // 3 billion rupiah
+		999999999999.99,  // just under a trillion
+		1000000000000.00, // a trillion
+	}
+
+	seen := make(map[string]float64)
+	for _, amount := range amounts {
+		key := formatAmount(money(amount))
+		if other, exists := seen[key]; exists {
+			t.Errorf("Amounts %.2f and %.2f share key %q", other, amount, key)
+		}
+		seen[key] = amount
+	}
+}
+
+func TestFormatAmount_Readable(t *testing.T) {
+	if key := formatAmount(money(2500000000.00)); key != "IDR250000000000" {
+		t.Errorf("Expected key IDR250000000000, got %q", key)
+	}
+}
+
+func TestExactMatcher_GenerateKey_LargeAmounts(t *testing.T) {
+	matcher := &ExactMatcher{config: DefaultConfig()}
+	date := time.Date(2024, 3, 15, 0, 0, 0, 0, time.UTC)
+
+	sys := createSystemTransaction("SYS001", "BCA", 2500000000.00, domain.TransactionTypeCredit, date)
+	same := createBankTransaction("BANK001", "BCA", 2500000000.00, domain.TransactionTypeCredit, date)
+	other := createBankTransaction("BANK002", "BCA", 3000000000.00, domain.TransactionTypeCredit, date)
+
+	if matcher.generateKey(sys) != matcher.generateKey(same) {
+		t.Errorf("Expected equal amounts to share a key, got %q and %q",
+			matcher.generateKey(sys), matcher.generateKey(same))
+	}
+
+	if matcher.generateKey(sys) == matcher.generateKey(other) {
+		t.Errorf("Expected different amounts to get different keys, both got %q", matcher.generateKey(sys))
+	}
+}
+
+func TestExactMatcher_Match_LargeIDRAmounts(t *testing.T) {
+	matcher := NewExactMatcher(DefaultConfig())
+	date := time.Date(2024, 3, 15, 0, 0, 0, 0, time.UTC)
+
+	// Distinct billion-rupiah disbursements on the same day must not be treated as ambiguous
+	systemTxns := []*transaction.Transaction{
+		createSystemTransaction("SYS001", "BCA", 2500000000.00, domain.TransactionTypeDebit, date),
+		createSystemTransaction("SYS002", "BCA", 3000000000.00, domain.TransactionTypeDebit, date),
+		createSystemTransaction("SYS003", "MANDIRI", 750000000.50, domain.TransactionTypeCredit, date),
+	}
+
+	bankTxns := []*transaction.Transaction{
+		createBankTransaction("BANK001", "BCA", -3000000000.00, domain.TransactionTypeDebit, date),
+		createBankTransaction("BANK002", "BCA", -2500000000.00, domain.TransactionTypeDebit, date),
+		createBankTransaction("BANK003", "MANDIRI", 750000000.50, domain.TransactionTypeCredit, date),
+		createBankTransaction("BANK004", "MANDIRI", 750000000.51, domain.TransactionTypeCredit, date),
+	}
+
+	result, err := matcher.Match(systemTxns, bankTxns)
+	if err != nil {
+		t.Fatalf("Match failed: %v", err)
+	}
+
+	if len(result.Matched) != 3 {
+		t.Fatalf("Expected 3 matches, got %d", len(result.Matched))
+	}
+
+	expected := map[string]string{"SYS001": "BANK002", "SYS002": "BANK001", "SYS003": "BANK003"}
+	for _, match := range result.Matched {
+		if expected[match.SystemTransaction.ID] != match.BankTransaction.ID {
+			t.Errorf("Expected %s ↔ %s, got %s",
+				match.SystemTransaction.ID, expected[match.SystemTransaction.ID], match.BankTransaction.ID)
+		}
+	}
+
+	if len(result.UnmatchedBank) != 1 || result.UnmatchedBank[0].ID != "BANK004" {
+		t.Errorf("Expected only BANK004 unmatched, got %d unmatched bank transactions", len(result.UnmatchedBank))
+	}
+}
+
+// Tests for source-aware matching (MatchSource)
+
+func TestExactMatcher_MatchSource_RejectsCrossBank(t *testing.T) {
+	matcher := NewExactMatcher(MatcherConfig{MatchSource: true})
+	date := time.Date(2024, 3, 15, 0, 0, 0, 0, time.UTC)
+
+	systemTxns := []*transaction.Transaction{
+		createSystemTransaction("SYS001", "BCA", 150.50, domain.TransactionTypeDebit, date),
+	}
+
+	bankTxns := []*transaction.Transaction{
+		createBankTransaction("BANK001", "MANDIRI", -150.50, domain.TransactionTypeDebit, date),
+	}
+
+	result, err := matcher.Match(systemTxns, bankTxns)
+	if err != nil {
+		t.Fatalf("Match failed: %v", err)
+	}
+
+	if len(result.Matched) != 0 {
+		t.Errorf("Expected 0 matches across banks, got %d", len(result.Matched))
+	}
+
+	if len(result.WrongBank) != 1 {
+		t.Fatalf("Expected 1 wrong bank exception, got %d", len(result.WrongBank))
+	}
+
+	if result.WrongBank[0].SystemTransaction.ID != "SYS001" || result.WrongBank[0].BankTransaction.ID != "BANK001" {
+		t.Errorf("Expected SYS001 ↔ BANK001 wrong bank pair, got %s ↔ %s",
+			result.WrongBank[0].SystemTransaction.ID, result.WrongBank[0].BankTransaction.ID)
+	}
+
+	// Wrong bank pairs still count as unmatched
+	if len(result.UnmatchedSystem) != 1 || len(result.UnmatchedBank) != 1 {
+		t.Errorf("Expected 1 unmatched on each side, got %d system and %d bank",
+			len(result.UnmatchedSystem), len(result.UnmatchedBank))
+	}
+}
+
+func TestExactMatcher_MatchSource_ResolvesAmbiguity(t *testing.T) {
+	matcher := NewExactMatcher(MatcherConfig{MatchSource: true})
+	date := time.Date(2024, 3, 15, 0, 0, 0, 0, time.UTC)
+
+	// Ambiguous without source, unique per bank with it
+	systemTxns := []*transaction.Transaction{
+		createSystemTransaction("SYS001", "BCA", 150.50, domain.TransactionTypeDebit, date),
+		createSystemTransaction("SYS002", "MANDIRI", 150.50, domain.TransactionTypeDebit, date),
+	}
+
+	bankTxns := []*transaction.Transaction{
+		createBankTransaction("BANK001", "BCA", -150.50, domain.TransactionTypeDebit, date),
+		createBankTransaction("BANK002", "MANDIRI", -150.50, domain.TransactionTypeDebit, date),
+	}
+
+	result, err := matcher.Match(systemTxns, bankTxns)
+	if err != nil {
+		t.Fatalf("Match failed: %v", err)
+	}
+
+	if len(result.Matched) != 2 {
+		t.Errorf("Expected 2 matches, got %d", len(result.Matched))
+	}
+
+	if len(result.WrongBank) != 0 {
+		t.Errorf("Expected 0 wrong bank exceptions, got %d", len(result.WrongBank))
+	}
+}
+
+func TestExactMatcher_IgnoreSource_NoWrongBank(t *testing.T) {
+	matcher := NewExactMatcher(DefaultConfig())
+	date := time.Date(2024, 3, 15, 0, 0, 0, 0, time.UTC)
+
+	systemTxns := []*transaction.Transaction{
+		createSystemTransaction("SYS001", "BCA", 150.50, domain.TransactionTypeDebit, date),
+	}
+
+	bankTxns := []*transaction.Transaction{
+		createBankTransaction("BANK001", "MANDIRI", -150.75, domain.TransactionTypeDebit, date),
+	}
+
+	result, err := matcher.Match(systemTxns, bankTxns)
+	if err != nil {
+		t.Fatalf("Match failed: %v", err)
+	}
+
+	if len(result.WrongBank) != 0 {
+		t.Errorf("Expected 0 wrong bank exceptions without MatchSource, got %d", len(result.WrongBank))
+	}
+}
+
 // Helper functions for creating test transactions
 
 func createSystemTransaction(id, source string, amount float64, txnType domain.TransactionType, date time.Time) *transaction.Transaction {
@@ -356,7 +536,7 @@ func createSystemTransaction(id, source string, amount float64, txnType domain.T
 		"test-file",
 		domain.SourceTypeSystem,
 		date,
-		amount,
+		money(amount),
 		txnType,
 		source,
 	)
@@ -371,7 +551,7 @@ func createBankTransaction(id, source string, amount float64, txnType domain.Tra
 		"test-file",
 		domain.SourceTypeBank,
 		date,
-		amount,
+		money(amount),
 		txnType,
 		source,
 	)
@@ -379,3 +559,12 @@ func createBankTransaction(id, source string, amount float64, txnType domain.Tra
 	txn.NormalizeAmount()
 	return txn
 }
+
+// money converts a test amount in rupiah to domain.Money
+func money(amount float64) domain.Money {
+	m, err := domain.MoneyFromFloat(amount, domain.CurrencyIDR)
+	if err != nil {
+		panic(err)
+	}
+	return m
+}
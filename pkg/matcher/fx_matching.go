@@ -0,0 +1,85 @@
+package matcher
+
+import (
+	"strings"
+
+	"github.com/farhaan/amartha-reconcile-system/internal/domain"
+	"github.com/farhaan/amartha-reconcile-system/internal/domain/transaction"
+)
+
+// fxMatchConfidence is the confidence of a pair matched after currency conversion.
+const fxMatchConfidence = 95.0
+
+// matchForeignCurrency pairs unmatched bank transactions in a foreign currency with
+// unmatched system transactions of the same date and type. The bank amount is converted
+// into the system currency with config.FXRates at the bank date, and the pair matches
+// when the converted amount is within FXTolerancePct. Whatever difference is left is
+// recorded as FXDiscrepancy, not AmountDiscrepancy. Equally close candidates are skipped.
+func matchForeignCurrency(result *MatchResult, config MatcherConfig) {
+	result.fxRates = config.FXRates
+
+	matchedSysTxns := make(map[*transaction.Transaction]bool)
+	matchedBankTxns := make(map[*transaction.Transaction]bool)
+
+	for _, bankTxn := range result.UnmatchedBank {
+		var best *transaction.Transaction
+		var bestDiff domain.Money
+		count := 0
+
+		for _, sysTxn := range result.UnmatchedSystem {
+			if matchedSysTxns[sysTxn] || sysTxn.AbsAmount().Currency == bankTxn.AbsAmount().Currency {
+				continue
+			}
+			if !isSameDay(sysTxn.TransactionDate, bankTxn.TransactionDate) || sysTxn.IsDebit() != bankTxn.IsDebit() {
+				continue
+			}
+			if config.MatchSource && !strings.EqualFold(sysTxn.Source, bankTxn.Source) {
+				continue
+			}
+
+			converted, err := config.FXRates.Convert(bankTxn.AbsAmount(), sysTxn.AbsAmount().Currency, bankTxn.TransactionDate)
+			if err != nil {
+				continue
+			}
+			diff := amountDifference(sysTxn.AbsAmount(), converted)
+			if diff.Cmp(sysTxn.AbsAmount().Percent(config.FXTolerancePct)) > 0 {
+				continue
+			}
+
+			switch {
+			case best == nil || diff.Cmp(bestDiff) < 0:
+				best, bestDiff, count = sysTxn, diff, 1
+			case amountsEqual(diff, bestDiff):
+				count++
+			}
+		}
+
+		if count != 1 {
+			continue
+		}
+
+		result.Matched = append(result.Matched, MatchPair{
+			SystemTransaction: best,
+			BankTransaction:   bankTxn,
+			ConfidenceScore:   fxMatchConfidence,
+			AmountDiscrepancy: domain.NewMoney(0, best.AbsAmount().Currency),
+			FXDiscrepancy:     bestDiff,
+		})
+		matchedSysTxns[best] = true
+		matchedBankTxns[bankTxn] = true
+	}
+
+	result.UnmatchedSystem = removeMatched(result.UnmatchedSystem, matchedSysTxns)
+	result.UnmatchedBank = removeMatched(result.UnmatchedBank, matchedBankTxns)
+}
+
+// removeMatched returns txns without the ones flagged in matched.
+func removeMatched(txns []*transaction.Transaction, matched map[*transaction.Transaction]bool) []*transaction.Transaction {
+	kept := make([]*transaction.Transaction, 0, len(txns))
+	for _, txn := range txns {
+		if !matched[txn] {
+			kept = append(kept, txn)
+		}
+	}
+	return kept
+}
@@ -0,0 +1,138 @@
+package matcher
+
+import (
+	"testing"
+	"time"
+
+	"github.com/farhaan/amartha-reconcile-system/internal/domain"
+	"github.com/farhaan/amartha-reconcile-system/internal/domain/fx"
+	"github.com/farhaan/amartha-reconcile-system/internal/domain/transaction"
+)
+
+func TestExactMatcher_FX_MatchesForeignCurrency(t *testing.T) {
+	date := time.Date(2024, 3, 15, 0, 0, 0, 0, time.UTC)
+	config := DefaultConfig()
+	config.FXRates = newTestRateTable(t, date, "15650.25")
+	matcher := NewExactMatcher(config)
+
+	systemTxns := []*transaction.Transaction{
+		createSystemTransaction("SYS001", "DBS", 1565025.00, domain.TransactionTypeCredit, date),
+		createSystemTransaction("SYS002", "DBS", 780000.00, domain.TransactionTypeDebit, date),
+	}
+
+	bankTxns := []*transaction.Transaction{
+		createForeignBankTransaction("BANK001", "DBS", 10000, domain.CurrencyUSD, domain.TransactionTypeCredit, date),
+		createForeignBankTransaction("BANK002", "DBS", -5000, domain.CurrencyUSD, domain.TransactionTypeDebit, date),
+	}
+
+	result, err := matcher.Match(systemTxns, bankTxns)
+	if err != nil {
+		t.Fatalf("Match failed: %v", err)
+	}
+
+	if len(result.Matched) != 2 {
+		t.Fatalf("Expected 2 matches, got %d", len(result.Matched))
+	}
+
+	for _, match := range result.Matched {
+		if !match.AmountDiscrepancy.IsZero() {
+			t.Errorf("Expected FX differences kept out of AmountDiscrepancy, got %s", match.AmountDiscrepancy)
+		}
+		if match.ConfidenceScore != fxMatchConfidence {
+			t.Errorf("Expected confidence %.0f, got %f", fxMatchConfidence, match.ConfidenceScore)
+		}
+	}
+
+	// 50 USD = 782,512.50 IDR against 780,000.00 booked
+	if !result.TotalFXDiscrepancy.Equal(money(2512.50)) {
+		t.Errorf("Expected FX discrepancy 2512.50, got %s", result.TotalFXDiscrepancy)
+	}
+
+	if !result.TotalDiscrepancy.IsZero() {
+		t.Errorf("Expected no real discrepancy, got %s", result.TotalDiscrepancy)
+	}
+}
+
+func TestExactMatcher_FX_OutsideTolerance(t *testing.T) {
+	date := time.Date(2024, 3, 15, 0, 0, 0, 0, time.UTC)
+	config := DefaultConfig()
+	config.FXRates = newTestRateTable(t, date, "15650.25")
+	config.FXTolerancePct = 0.1
+	matcher := NewExactMatcher(config)
+
+	systemTxns := []*transaction.Transaction{
+		createSystemTransaction("SYS001", "DBS", 780000.00, domain.TransactionTypeDebit, date),
+	}
+
+	bankTxns := []*transaction.Transaction{
+		createForeignBankTransaction("BANK001", "DBS", -5000, domain.CurrencyUSD, domain.TransactionTypeDebit, date),
+	}
+
+	result, err := matcher.Match(systemTxns, bankTxns)
+	if err != nil {
+		t.Fatalf("Match failed: %v", err)
+	}
+
+	if len(result.Matched) != 0 {
+		t.Errorf("Expected 0 matches outside FX tolerance, got %d", len(result.Matched))
+	}
+
+	// Unmatched USD line is converted into the IDR total: 782,512.50 + 780,000.00
+	if !result.TotalDiscrepancy.Equal(money(1562512.50)) {
+		t.Errorf("Expected total discrepancy 1562512.50, got %s", result.TotalDiscrepancy)
+	}
+}
+
+func TestExactMatcher_FX_NoRatesKeepsCurrenciesApart(t *testing.T) {
+	date := time.Date(2024, 3, 15, 0, 0, 0, 0, time.UTC)
+	matcher := NewExactMatcher(DefaultConfig())
+
+	systemTxns := []*transaction.Transaction{
+		createSystemTransaction("SYS001", "DBS", 1565025.00, domain.TransactionTypeCredit, date),
+	}
+
+	bankTxns := []*transaction.Transaction{
+		createForeignBankTransaction("BANK001", "DBS", 10000, domain.CurrencyUSD, domain.TransactionTypeCredit, date),
+	}
+
+	result, err := matcher.Match(systemTxns, bankTxns)
+	if err != nil {
+		t.Fatalf("Match failed: %v", err)
+	}
+
+	if len(result.Matched) != 0 {
+		t.Errorf("Expected 0 matches without FX rates, got %d", len(result.Matched))
+	}
+
+	if got := result.UnconvertedDiscrepancy[domain.CurrencyUSD]; got.Minor != 10000 {
+		t.Errorf("Expected 100.00 USD unconverted, got %s", got)
+	}
+
+	if !result.TotalDiscrepancy.Equal(money(1565025.00)) {
+		t.Errorf("Expected IDR total to only hold the system amount, got %s", result.TotalDiscrepancy)
+	}
+}
+
+func newTestRateTable(t *testing.T, date time.Time, usdToIDR string) *fx.RateTable {
+	t.Helper()
+	table := fx.NewRateTable()
+	if err := table.AddRate(date, domain.CurrencyUSD, domain.CurrencyIDR, usdToIDR); err != nil {
+		t.Fatalf("AddRate failed: %v", err)
+	}
+	return table
+}
+
+func createForeignBankTransaction(id, source string, minor int64, currency domain.Currency, txnType domain.TransactionType, date time.Time) *transaction.Transaction {
+	txn := transaction.NewTransaction(
+		"test-job",
+		"test-file",
+		domain.SourceTypeBank,
+		date,
+		domain.NewMoney(minor, currency),
+		txnType,
+		source,
+	)
+	txn.ID = id
+	txn.NormalizeAmount()
+	return txn
+}
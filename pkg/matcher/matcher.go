@@ -1,20 +1,62 @@
 package matcher
 
 import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/farhaan/amartha-reconcile-system/internal/domain"
+	"github.com/farhaan/amartha-reconcile-system/internal/domain/fx"
 	"github.com/farhaan/amartha-reconcile-system/internal/domain/transaction"
 )
 
 // MatchResult contains the results of a matching operation
 type MatchResult struct {
-	Matched          []MatchPair
-	UnmatchedSystem  []*transaction.Transaction
-	UnmatchedBank    []*transaction.Transaction
-	AlgorithmUsed    string
-	MatchRate        float64
-	TotalSystemTxns  int
-	TotalBankTxns    int
-	TotalMatched     int
-	TotalDiscrepancy float64
+	Matched         []MatchPair
+	Grouped         []MatchGroup // Many-to-one and one-to-many matches
+	UnmatchedSystem []*transaction.Transaction
+	UnmatchedBank   []*transaction.Transaction
+	WrongBank       []MatchPair // Unmatched pairs that only differ by source bank (when MatchSource is set)
+	AlgorithmUsed   string
+	MatchRate       float64
+	TotalSystemTxns int
+	TotalBankTxns   int
+	TotalMatched    int
+
+	// TotalDiscrepancy is the gross exposure in BaseCurrency: MatchedDiscrepancy plus the
+	// four unmatched totals below
+	TotalDiscrepancy domain.Money
+
+	// MatchedDiscrepancy sums the amount differences of matched pairs and groups, in BaseCurrency
+	MatchedDiscrepancy domain.Money
+
+	// Absolute amounts left unmatched, by side and type, in BaseCurrency
+	UnmatchedSystemDebits  domain.Money
+	UnmatchedSystemCredits domain.Money
+	UnmatchedBankDebits    domain.Money
+	UnmatchedBankCredits   domain.Money
+
+	// NetDifference is the signed sum of system minus bank amounts, in BaseCurrency. Money that
+	// is on both sides but misdated or booked against another bank cancels out here, where
+	// TotalDiscrepancy would count it twice. Amounts without an FX rate are left out.
+	NetDifference domain.Money
+
+	// TotalFXDiscrepancy sums the FX-induced differences of cross-currency pairs, in BaseCurrency
+	TotalFXDiscrepancy domain.Money
+
+	// UnconvertedDiscrepancy holds foreign currency amounts that could not be converted to BaseCurrency
+	UnconvertedDiscrepancy map[domain.Currency]domain.Money
+
+	// BaseCurrency is the currency totals are reported in
+	BaseCurrency domain.Currency
+
+	// BySource, ByType and ByDate break the statistics down by bank, transaction type
+	// and day (YYYY-MM-DD), sorted by key
+	BySource []Breakdown
+	ByType   []Breakdown
+	ByDate   []Breakdown
+
+	fxRates *fx.RateTable
 }
 
 // MatchPair represents a matched pair of transactions
@@ -22,19 +64,54 @@ type MatchPair struct {
 	SystemTransaction *transaction.Transaction
 	BankTransaction   *transaction.Transaction
 	ConfidenceScore   float64 // 0-100, 100 = exact match
-	AmountDiscrepancy float64
+	AmountDiscrepancy domain.Money
+	FXDiscrepancy     domain.Money // Difference left after converting the bank amount to the system currency
+	DayOffset         int          // Bank date minus system date in days, 0 = same day
+}
+
+// MatchGroup represents several transactions on one side matched to one on the other,
+// e.g. disbursements batched into a single bank line or a payout split across bank credits
+type MatchGroup struct {
+	SystemTransactions []*transaction.Transaction
+	BankTransactions   []*transaction.Transaction
+	ConfidenceScore    float64 // 0-100
+	AmountDiscrepancy  domain.Money
 }
 
 // MatcherConfig configures the matching behavior
 type MatcherConfig struct {
 	// AmountTolerancePct is the percentage tolerance for amount matching (for fuzzy matchers)
 	AmountTolerancePct float64
+
+	// AmountToleranceAbs caps the absolute amount difference allowed by AmountTolerancePct (0 = no cap)
+	AmountToleranceAbs domain.Money
+
+	// MatchSource restricts candidates to bank transactions from the system transaction's source bank
+	MatchSource bool
+
+	// MaxGroupSize is the most transactions that may be combined into one side of a MatchGroup (for split matchers)
+	MaxGroupSize int
+
+	// DateWindowDays is how many days apart system and bank dates may be (for date window matchers)
+	DateWindowDays int
+
+	// FXRates converts foreign currency bank lines into the system transaction's currency (nil = no cross-currency matching)
+	FXRates *fx.RateTable
+
+	// FXTolerancePct is the percentage difference allowed after FX conversion, attributed to rate differences
+	FXTolerancePct float64
 }
 
 // DefaultConfig returns the default matcher configuration
 func DefaultConfig() MatcherConfig {
 	return MatcherConfig{
 		AmountTolerancePct: 0.0, // Exact match
+		AmountToleranceAbs: domain.Money{},
+		MatchSource:        false,
+		MaxGroupSize:       4,
+		DateWindowDays:     3,
+		FXRates:            nil,
+		FXTolerancePct:     1.0,
 	}
 }
 
@@ -51,6 +128,24 @@ type TransactionMatcher interface {
 	SetConfig(config MatcherConfig)
 }
 
+// NewMatcher creates the matcher registered under the given name
+func NewMatcher(name string, config MatcherConfig) (TransactionMatcher, error) {
+	switch name {
+	case "exact":
+		return NewExactMatcher(config), nil
+	case "date_window":
+		return NewDateWindowMatcher(config), nil
+	case "tolerance":
+		return NewToleranceMatcher(config), nil
+	case "assignment":
+		return NewAssignmentMatcher(config), nil
+	case "split":
+		return NewSplitMatcher(config), nil
+	default:
+		return nil, fmt.Errorf("unknown matcher %q", name)
+	}
+}
+
 // CalculateMatchRate computes the match rate as a percentage
 func CalculateMatchRate(totalMatched, totalSystem, totalBank int) float64 {
 	if totalSystem == 0 && totalBank == 0 {
@@ -65,34 +160,129 @@ func CalculateMatchRate(totalMatched, totalSystem, totalBank int) float64 {
 func NewMatchResult(algorithmName string) *MatchResult {
 	return &MatchResult{
 		Matched:         make([]MatchPair, 0),
+		Grouped:         make([]MatchGroup, 0),
 		UnmatchedSystem: make([]*transaction.Transaction, 0),
 		UnmatchedBank:   make([]*transaction.Transaction, 0),
+		WrongBank:       make([]MatchPair, 0),
 		AlgorithmUsed:   algorithmName,
+		BaseCurrency:    domain.DefaultCurrency,
 	}
 }
 
 // Finalize finalizes the match result by calculating statistics
 func (mr *MatchResult) Finalize() {
-	mr.TotalSystemTxns = len(mr.Matched) + len(mr.UnmatchedSystem)
-	mr.TotalBankTxns = len(mr.Matched) + len(mr.UnmatchedBank)
-	mr.TotalMatched = len(mr.Matched)
-	mr.MatchRate = CalculateMatchRate(mr.TotalMatched, mr.TotalSystemTxns, mr.TotalBankTxns)
+	groupedSystem, groupedBank := 0, 0
+	for _, group := range mr.Grouped {
+		groupedSystem += len(group.SystemTransactions)
+		groupedBank += len(group.BankTransactions)
+	}
+
+	mr.TotalSystemTxns = len(mr.Matched) + groupedSystem + len(mr.UnmatchedSystem)
+	mr.TotalBankTxns = len(mr.Matched) + groupedBank + len(mr.UnmatchedBank)
+	mr.TotalMatched = len(mr.Matched) + len(mr.Grouped)
+	mr.MatchRate = CalculateMatchRate(len(mr.Matched), mr.TotalSystemTxns, mr.TotalBankTxns)
+	if len(mr.Grouped) > 0 {
+		// Grouped matches cover more than two transactions each, so count transactions rather than pairs
+		matchedTxns := len(mr.Matched)*2 + groupedSystem + groupedBank
+		mr.MatchRate = (float64(matchedTxns) / float64(mr.TotalSystemTxns+mr.TotalBankTxns)) * 100.0
+	}
 
-	// Calculate total discrepancy: matched pair differences + all unmatched amounts
-	mr.TotalDiscrepancy = 0
+	zero := domain.NewMoney(0, mr.BaseCurrency)
+	mr.TotalFXDiscrepancy = zero
+	mr.MatchedDiscrepancy = zero
+	mr.UnmatchedSystemDebits, mr.UnmatchedSystemCredits = zero, zero
+	mr.UnmatchedBankDebits, mr.UnmatchedBankCredits = zero, zero
+	mr.UnconvertedDiscrepancy = make(map[domain.Currency]domain.Money)
 
-	// Add amount differences from matched pairs
+	// Add amount differences from matched pairs, keeping FX differences apart
 	for _, pair := range mr.Matched {
-		mr.TotalDiscrepancy += pair.AmountDiscrepancy
+		mr.addDiscrepancy(&mr.MatchedDiscrepancy, pair.AmountDiscrepancy, pair.SystemTransaction.TransactionDate)
+		mr.addDiscrepancy(&mr.TotalFXDiscrepancy, pair.FXDiscrepancy, pair.SystemTransaction.TransactionDate)
+	}
+
+	// Add amount differences from grouped matches
+	for _, group := range mr.Grouped {
+		mr.addDiscrepancy(&mr.MatchedDiscrepancy, group.AmountDiscrepancy, group.BankTransactions[0].TransactionDate)
 	}
 
 	// Add all unmatched system transaction amounts
 	for _, txn := range mr.UnmatchedSystem {
-		mr.TotalDiscrepancy += txn.AbsAmount()
+		total := &mr.UnmatchedSystemCredits
+		if txn.IsDebit() {
+			total = &mr.UnmatchedSystemDebits
+		}
+		mr.addDiscrepancy(total, txn.AbsAmount(), txn.TransactionDate)
 	}
 
 	// Add all unmatched bank transaction amounts
 	for _, txn := range mr.UnmatchedBank {
-		mr.TotalDiscrepancy += txn.AbsAmount()
+		total := &mr.UnmatchedBankCredits
+		if txn.IsDebit() {
+			total = &mr.UnmatchedBankDebits
+		}
+		mr.addDiscrepancy(total, txn.AbsAmount(), txn.TransactionDate)
+	}
+
+	// Total discrepancy: matched pair and group differences + all unmatched amounts
+	mr.TotalDiscrepancy = mr.MatchedDiscrepancy.
+		Add(mr.UnmatchedSystemDebits).Add(mr.UnmatchedSystemCredits).
+		Add(mr.UnmatchedBankDebits).Add(mr.UnmatchedBankCredits)
+
+	mr.BySource = mr.breakdown(breakdownBySource)
+	mr.ByType = mr.breakdown(breakdownByType)
+	mr.ByDate = mr.breakdown(breakdownByDate)
+
+	mr.NetDifference = zero
+	for _, b := range mr.BySource {
+		mr.NetDifference = mr.NetDifference.Add(b.NetDifference)
+	}
+}
+
+// addDiscrepancy adds amount to total in BaseCurrency, converting with the FX rates of
+// the given day when needed. Amounts that cannot be converted are kept per currency.
+func (mr *MatchResult) addDiscrepancy(total *domain.Money, amount domain.Money, date time.Time) {
+	if amount.IsZero() {
+		return
+	}
+	if converted, ok := mr.toBaseCurrency(amount, date); ok {
+		*total = total.Add(converted)
+		return
+	}
+	mr.UnconvertedDiscrepancy[amount.Currency] = mr.UnconvertedDiscrepancy[amount.Currency].Add(amount)
+}
+
+// FlagWrongBank pairs up unmatched system and bank transactions that share date, type
+// and amount but come from different source banks. Both sides stay unmatched; the pairs
+// are reported as "wrong bank" exceptions for manual review.
+func (mr *MatchResult) FlagWrongBank() {
+	mr.WrongBank = make([]MatchPair, 0)
+	flaggedBankTxns := make(map[*transaction.Transaction]bool)
+
+	for _, sysTxn := range mr.UnmatchedSystem {
+		for _, bankTxn := range mr.UnmatchedBank {
+			if flaggedBankTxns[bankTxn] || strings.EqualFold(sysTxn.Source, bankTxn.Source) {
+				continue
+			}
+			if !isSameDay(sysTxn.TransactionDate, bankTxn.TransactionDate) ||
+				sysTxn.IsDebit() != bankTxn.IsDebit() ||
+				!amountsEqual(sysTxn.AbsAmount(), bankTxn.AbsAmount()) {
+				continue
+			}
+			mr.WrongBank = append(mr.WrongBank, MatchPair{
+				SystemTransaction: sysTxn,
+				BankTransaction:   bankTxn,
+				ConfidenceScore:   0,
+			})
+			flaggedBankTxns[bankTxn] = true
+			break
+		}
+	}
+}
+
+// sourceKey returns the key suffix that keeps banks apart when MatchSource is set.
+func sourceKey(txn *transaction.Transaction, config MatcherConfig) string {
+	if !config.MatchSource {
+		return ""
 	}
+	return "_" + strings.ToUpper(txn.Source)
 }
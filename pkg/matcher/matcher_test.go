@@ -0,0 +1,73 @@
+package matcher
+
+import (
+	"testing"
+	"time"
+
+	"github.com/farhaan/amartha-reconcile-system/internal/domain"
+	"github.com/farhaan/amartha-reconcile-system/internal/domain/transaction"
+)
+
+func TestMatchResult_Finalize_DiscrepancyMetrics(t *testing.T) {
+	day1 := time.Date(2024, 3, 15, 0, 0, 0, 0, time.UTC)
+	day2 := day1.AddDate(0, 0, 1)
+
+	result := NewMatchResult("exact")
+	result.Matched = append(result.Matched, MatchPair{
+		SystemTransaction: createSystemTransaction("SYS001", "BCA", 50.00, domain.TransactionTypeCredit, day1),
+		BankTransaction:   createBankTransaction("BANK001", "BCA", 49.50, domain.TransactionTypeCredit, day1),
+		AmountDiscrepancy: money(0.50),
+	})
+	result.UnmatchedSystem = append(result.UnmatchedSystem,
+		// Booked a day late by the bank, so unmatched on both sides
+		createSystemTransaction("SYS002", "BCA", 100.00, domain.TransactionTypeCredit, day1),
+		createSystemTransaction("SYS003", "BCA", 30.00, domain.TransactionTypeDebit, day1),
+	)
+	result.UnmatchedBank = append(result.UnmatchedBank,
+		createBankTransaction("BANK002", "BCA", 100.00, domain.TransactionTypeCredit, day2),
+		createBankTransaction("BANK003", "MANDIRI", 20.00, domain.TransactionTypeDebit, day2),
+	)
+	result.Finalize()
+
+	tests := []struct {
+		name     string
+		got      domain.Money
+		expected domain.Money
+	}{
+		{"matched discrepancy", result.MatchedDiscrepancy, money(0.50)},
+		{"unmatched system debits", result.UnmatchedSystemDebits, money(30.00)},
+		{"unmatched system credits", result.UnmatchedSystemCredits, money(100.00)},
+		{"unmatched bank debits", result.UnmatchedBankDebits, money(20.00)},
+		{"unmatched bank credits", result.UnmatchedBankCredits, money(100.00)},
+		{"total discrepancy", result.TotalDiscrepancy, money(250.50)},
+		// System 50 + 100 - 30 against bank 49.50 + 100 - 20: the misdated 100 cancels out
+		{"net difference", result.NetDifference, money(-9.50)},
+		{"BCA net difference", result.BySource[0].NetDifference, money(-29.50)},
+		{"MANDIRI net difference", result.BySource[1].NetDifference, money(20.00)},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if !tt.got.Equal(tt.expected) {
+				t.Errorf("Expected %s, got %s", tt.expected, tt.got)
+			}
+		})
+	}
+}
+
+func TestMatchResult_Finalize_NetDifferenceSkipsUnconverted(t *testing.T) {
+	date := time.Date(2024, 3, 15, 0, 0, 0, 0, time.UTC)
+
+	result := NewMatchResult("exact")
+	result.UnmatchedBank = []*transaction.Transaction{
+		createForeignBankTransaction("BANK001", "DBS", 1000, domain.CurrencyUSD, domain.TransactionTypeCredit, date),
+	}
+	result.Finalize()
+
+	if !result.NetDifference.IsZero() {
+		t.Errorf("Expected amounts without a rate left out of the net difference, got %s", result.NetDifference)
+	}
+	if usd := result.UnconvertedDiscrepancy[domain.CurrencyUSD]; usd.String() != "10.00" {
+		t.Errorf("Expected 10.00 USD unconverted, got %s", usd)
+	}
+}
@@ -0,0 +1,183 @@
+package matcher
+
+import (
+	"strings"
+
+	"github.com/farhaan/amartha-reconcile-system/internal/domain/transaction"
+)
+
+const (
+	// splitGroupConfidence is the confidence of a many-to-one or one-to-many match.
+	splitGroupConfidence = 80.0
+
+	// maxSplitCandidates caps how many same-day transactions are searched for a subset,
+	// days busier than this are left for manual review instead of an exponential search.
+	maxSplitCandidates = 25
+)
+
+// SplitMatcher matches transactions one-to-one like ExactMatcher, then pairs what is
+// left over as groups: several system transactions summing to one bank line (batched
+// disbursements) or one system transaction summing several bank lines (split payouts).
+type SplitMatcher struct {
+	config MatcherConfig
+}
+
+func NewSplitMatcher(config MatcherConfig) TransactionMatcher {
+	return &SplitMatcher{
+		config: config,
+	}
+}
+
+func (sm *SplitMatcher) SetConfig(config MatcherConfig) {
+	sm.config = config
+}
+
+func (sm *SplitMatcher) Name() string {
+	return "split"
+}
+
+// Match runs exact one-to-one matching first, then looks for groups among the
+// unmatched transactions. Group members must share date, type, and source bank, and
+// their amounts must sum to the single transaction on the other side. If more than one
+// subset adds up, the group is ambiguous and everything stays unmatched.
+func (sm *SplitMatcher) Match(systemTxns, bankTxns []*transaction.Transaction) (*MatchResult, error) {
+	result, err := NewExactMatcher(sm.config).Match(systemTxns, bankTxns)
+	if err != nil {
+		return nil, err
+	}
+	result.AlgorithmUsed = sm.Name()
+
+	maxSize := sm.config.MaxGroupSize
+	if maxSize < 2 {
+		return result, nil
+	}
+
+	// Many system transactions batched into one bank line
+	for _, bankTxn := range result.UnmatchedBank {
+		members := sm.findGroup(bankTxn, result.UnmatchedSystem, maxSize)
+		if members == nil {
+			continue
+		}
+		result.Grouped = append(result.Grouped, MatchGroup{
+			SystemTransactions: members,
+			BankTransactions:   []*transaction.Transaction{bankTxn},
+			ConfidenceScore:    splitGroupConfidence,
+		})
+		result.UnmatchedSystem = removeTransactions(result.UnmatchedSystem, members)
+	}
+	result.UnmatchedBank = removeGrouped(result.UnmatchedBank, result.Grouped, false)
+
+	// One system transaction split across several bank lines
+	for _, sysTxn := range result.UnmatchedSystem {
+		members := sm.findGroup(sysTxn, result.UnmatchedBank, maxSize)
+		if members == nil {
+			continue
+		}
+		result.Grouped = append(result.Grouped, MatchGroup{
+			SystemTransactions: []*transaction.Transaction{sysTxn},
+			BankTransactions:   members,
+			ConfidenceScore:    splitGroupConfidence,
+		})
+		result.UnmatchedBank = removeTransactions(result.UnmatchedBank, members)
+	}
+	result.UnmatchedSystem = removeGrouped(result.UnmatchedSystem, result.Grouped, true)
+
+	if sm.config.MatchSource {
+		result.FlagWrongBank()
+	}
+
+	result.Finalize()
+	return result, nil
+}
+
+// findGroup returns the unique subset of candidates (at least two) on the same date,
+// type, source, and currency as target whose amounts sum to target's amount, or nil if there is none.
+func (sm *SplitMatcher) findGroup(target *transaction.Transaction, candidates []*transaction.Transaction, maxSize int) []*transaction.Transaction {
+	pool := make([]*transaction.Transaction, 0)
+	for _, txn := range candidates {
+		if dateTypeKey(txn) == dateTypeKey(target) && strings.EqualFold(txn.Source, target.Source) &&
+			txn.AbsAmount().Currency == target.AbsAmount().Currency {
+			pool = append(pool, txn)
+		}
+	}
+	if len(pool) < 2 || len(pool) > maxSplitCandidates {
+		return nil
+	}
+
+	amounts := make([]int64, len(pool))
+	for i, txn := range pool {
+		amounts[i] = txn.AbsAmount().Minor
+	}
+
+	indices, unique := findUniqueSubset(target.AbsAmount().Minor, amounts, maxSize)
+	if !unique || len(indices) < 2 {
+		return nil
+	}
+
+	members := make([]*transaction.Transaction, len(indices))
+	for i, idx := range indices {
+		members[i] = pool[idx]
+	}
+	return members
+}
+
+// findUniqueSubset searches for subsets of up to maxSize amounts that sum to target.
+// Returns the indices of the subset and true only if exactly one such subset exists.
+func findUniqueSubset(target int64, amounts []int64, maxSize int) ([]int, bool) {
+	var found []int
+	solutions := 0
+	current := make([]int, 0, maxSize)
+
+	var search func(start int, remaining int64)
+	search = func(start int, remaining int64) {
+		if solutions > 1 {
+			return
+		}
+		if remaining == 0 && len(current) > 0 {
+			solutions++
+			found = append([]int(nil), current...)
+			return
+		}
+		if len(current) == maxSize || remaining < 0 {
+			return
+		}
+		for i := start; i < len(amounts); i++ {
+			current = append(current, i)
+			search(i+1, remaining-amounts[i])
+			current = current[:len(current)-1]
+		}
+	}
+	search(0, target)
+
+	return found, solutions == 1
+}
+
+// removeTransactions returns txns without the given members.
+func removeTransactions(txns, members []*transaction.Transaction) []*transaction.Transaction {
+	drop := make(map[*transaction.Transaction]bool)
+	for _, txn := range members {
+		drop[txn] = true
+	}
+
+	kept := make([]*transaction.Transaction, 0, len(txns))
+	for _, txn := range txns {
+		if !drop[txn] {
+			kept = append(kept, txn)
+		}
+	}
+	return kept
+}
+
+// removeGrouped returns txns without any transaction already placed in a group,
+// looking at the system side of each group when system is true, the bank side otherwise.
+func removeGrouped(txns []*transaction.Transaction, groups []MatchGroup, system bool) []*transaction.Transaction {
+	members := make([]*transaction.Transaction, 0)
+	for _, group := range groups {
+		if system {
+			members = append(members, group.SystemTransactions...)
+		} else {
+			members = append(members, group.BankTransactions...)
+		}
+	}
+	return removeTransactions(txns, members)
+}
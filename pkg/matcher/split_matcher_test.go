@@ -0,0 +1,195 @@
+package matcher
+
+import (
+	"testing"
+	"time"
+
+	"github.com/farhaan/amartha-reconcile-system/internal/domain"
+	"github.com/farhaan/amartha-reconcile-system/internal/domain/transaction"
+)
+
+func TestSplitMatcher_Name(t *testing.T) {
+	matcher := NewSplitMatcher(DefaultConfig())
+	if matcher.Name() != "split" {
+		t.Errorf("Expected name 'split', got %s", matcher.Name())
+	}
+}
+
+func TestSplitMatcher_Match_ManyToOne(t *testing.T) {
+	matcher := NewSplitMatcher(DefaultConfig())
+	date := time.Date(2024, 3, 15, 0, 0, 0, 0, time.UTC)
+
+	// Three disbursements batched into one bank debit
+	systemTxns := []*transaction.Transaction{
+		createSystemTransaction("SYS001", "BCA", 100.00, domain.TransactionTypeDebit, date),
+		createSystemTransaction("SYS002", "BCA", 250.50, domain.TransactionTypeDebit, date),
+		createSystemTransaction("SYS003", "BCA", 49.50, domain.TransactionTypeDebit, date),
+	}
+
+	bankTxns := []*transaction.Transaction{
+		createBankTransaction("BANK001", "BCA", -400.00, domain.TransactionTypeDebit, date),
+	}
+
+	result, err := matcher.Match(systemTxns, bankTxns)
+	if err != nil {
+		t.Fatalf("Match failed: %v", err)
+	}
+
+	if len(result.Grouped) != 1 {
+		t.Fatalf("Expected 1 grouped match, got %d", len(result.Grouped))
+	}
+
+	if len(result.Grouped[0].SystemTransactions) != 3 || len(result.Grouped[0].BankTransactions) != 1 {
+		t.Errorf("Expected 3:1 group, got %d:%d",
+			len(result.Grouped[0].SystemTransactions), len(result.Grouped[0].BankTransactions))
+	}
+
+	if len(result.UnmatchedSystem) != 0 || len(result.UnmatchedBank) != 0 {
+		t.Errorf("Expected nothing unmatched, got %d system and %d bank",
+			len(result.UnmatchedSystem), len(result.UnmatchedBank))
+	}
+
+	if result.TotalSystemTxns != 3 || result.TotalBankTxns != 1 {
+		t.Errorf("Expected 3 system and 1 bank total, got %d and %d", result.TotalSystemTxns, result.TotalBankTxns)
+	}
+
+	if result.MatchRate != 100.0 {
+		t.Errorf("Expected 100%% match rate, got %f", result.MatchRate)
+	}
+}
+
+func TestSplitMatcher_Match_OneToMany(t *testing.T) {
+	matcher := NewSplitMatcher(DefaultConfig())
+	date := time.Date(2024, 3, 15, 0, 0, 0, 0, time.UTC)
+
+	// One payout arrives as two bank credits
+	systemTxns := []*transaction.Transaction{
+		createSystemTransaction("SYS001", "MANDIRI", 1000.00, domain.TransactionTypeCredit, date),
+	}
+
+	bankTxns := []*transaction.Transaction{
+		createBankTransaction("BANK001", "MANDIRI", 600.00, domain.TransactionTypeCredit, date),
+		createBankTransaction("BANK002", "MANDIRI", 400.00, domain.TransactionTypeCredit, date),
+	}
+
+	result, err := matcher.Match(systemTxns, bankTxns)
+	if err != nil {
+		t.Fatalf("Match failed: %v", err)
+	}
+
+	if len(result.Grouped) != 1 {
+		t.Fatalf("Expected 1 grouped match, got %d", len(result.Grouped))
+	}
+
+	if len(result.Grouped[0].SystemTransactions) != 1 || len(result.Grouped[0].BankTransactions) != 2 {
+		t.Errorf("Expected 1:2 group, got %d:%d",
+			len(result.Grouped[0].SystemTransactions), len(result.Grouped[0].BankTransactions))
+	}
+}
+
+func TestSplitMatcher_Match_ExactPairsFirst(t *testing.T) {
+	matcher := NewSplitMatcher(DefaultConfig())
+	date := time.Date(2024, 3, 15, 0, 0, 0, 0, time.UTC)
+
+	systemTxns := []*transaction.Transaction{
+		createSystemTransaction("SYS001", "BCA", 150.50, domain.TransactionTypeDebit, date),
+		createSystemTransaction("SYS002", "BCA", 100.00, domain.TransactionTypeDebit, date),
+		createSystemTransaction("SYS003", "BCA", 50.00, domain.TransactionTypeDebit, date),
+	}
+
+	bankTxns := []*transaction.Transaction{
+		createBankTransaction("BANK001", "BCA", -150.50, domain.TransactionTypeDebit, date),
+		createBankTransaction("BANK002", "BCA", -150.00, domain.TransactionTypeDebit, date),
+	}
+
+	result, err := matcher.Match(systemTxns, bankTxns)
+	if err != nil {
+		t.Fatalf("Match failed: %v", err)
+	}
+
+	if len(result.Matched) != 1 || result.Matched[0].BankTransaction.ID != "BANK001" {
+		t.Errorf("Expected BANK001 matched one-to-one, got %d pairs", len(result.Matched))
+	}
+
+	if len(result.Grouped) != 1 || result.Grouped[0].BankTransactions[0].ID != "BANK002" {
+		t.Errorf("Expected BANK002 matched as a group, got %d groups", len(result.Grouped))
+	}
+}
+
+func TestSplitMatcher_Match_AmbiguousSubset(t *testing.T) {
+	matcher := NewSplitMatcher(DefaultConfig())
+	date := time.Date(2024, 3, 15, 0, 0, 0, 0, time.UTC)
+
+	// Both 100+200 and 150+150 add up to 300
+	systemTxns := []*transaction.Transaction{
+		createSystemTransaction("SYS001", "BCA", 100.00, domain.TransactionTypeDebit, date),
+		createSystemTransaction("SYS002", "BCA", 200.00, domain.TransactionTypeDebit, date),
+		createSystemTransaction("SYS003", "BCA", 150.00, domain.TransactionTypeDebit, date),
+		createSystemTransaction("SYS004", "BCA", 150.00, domain.TransactionTypeDebit, date),
+	}
+
+	bankTxns := []*transaction.Transaction{
+		createBankTransaction("BANK001", "BCA", -300.00, domain.TransactionTypeDebit, date),
+	}
+
+	result, err := matcher.Match(systemTxns, bankTxns)
+	if err != nil {
+		t.Fatalf("Match failed: %v", err)
+	}
+
+	if len(result.Grouped) != 0 {
+		t.Errorf("Expected 0 grouped matches (ambiguous), got %d", len(result.Grouped))
+	}
+
+	if len(result.UnmatchedSystem) != 4 || len(result.UnmatchedBank) != 1 {
+		t.Errorf("Expected everything unmatched, got %d system and %d bank",
+			len(result.UnmatchedSystem), len(result.UnmatchedBank))
+	}
+}
+
+func TestSplitMatcher_Match_RequiresSameSource(t *testing.T) {
+	matcher := NewSplitMatcher(DefaultConfig())
+	date := time.Date(2024, 3, 15, 0, 0, 0, 0, time.UTC)
+
+	systemTxns := []*transaction.Transaction{
+		createSystemTransaction("SYS001", "BCA", 100.00, domain.TransactionTypeDebit, date),
+		createSystemTransaction("SYS002", "MANDIRI", 200.00, domain.TransactionTypeDebit, date),
+	}
+
+	bankTxns := []*transaction.Transaction{
+		createBankTransaction("BANK001", "BCA", -300.00, domain.TransactionTypeDebit, date),
+	}
+
+	result, err := matcher.Match(systemTxns, bankTxns)
+	if err != nil {
+		t.Fatalf("Match failed: %v", err)
+	}
+
+	if len(result.Grouped) != 0 {
+		t.Errorf("Expected 0 grouped matches across banks, got %d", len(result.Grouped))
+	}
+}
+
+func TestSplitMatcher_Match_MaxGroupSize(t *testing.T) {
+	matcher := NewSplitMatcher(MatcherConfig{MaxGroupSize: 2})
+	date := time.Date(2024, 3, 15, 0, 0, 0, 0, time.UTC)
+
+	systemTxns := []*transaction.Transaction{
+		createSystemTransaction("SYS001", "BCA", 100.00, domain.TransactionTypeDebit, date),
+		createSystemTransaction("SYS002", "BCA", 100.00, domain.TransactionTypeDebit, date),
+		createSystemTransaction("SYS003", "BCA", 100.00, domain.TransactionTypeDebit, date),
+	}
+
+	bankTxns := []*transaction.Transaction{
+		createBankTransaction("BANK001", "BCA", -300.00, domain.TransactionTypeDebit, date),
+	}
+
+	result, err := matcher.Match(systemTxns, bankTxns)
+	if err != nil {
+		t.Fatalf("Match failed: %v", err)
+	}
+
+	if len(result.Grouped) != 0 {
+		t.Errorf("Expected 0 grouped matches above max group size, got %d", len(result.Grouped))
+	}
+}
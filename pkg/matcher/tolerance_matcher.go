@@ -0,0 +1,155 @@
+package matcher
+
+import (
+	"math"
+
+	"github.com/farhaan/amartha-reconcile-system/internal/domain"
+	"github.com/farhaan/amartha-reconcile-system/internal/domain/transaction"
+)
+
+// toleranceConfidenceFloor is the confidence of a pair whose gap uses the whole tolerance.
+const toleranceConfidenceFloor = 50.0
+
+// ToleranceMatcher matches transactions by date and type when their amounts differ
+// by no more than MatcherConfig.AmountTolerancePct (capped by AmountToleranceAbs).
+type ToleranceMatcher struct {
+	config MatcherConfig
+}
+
+func NewToleranceMatcher(config MatcherConfig) TransactionMatcher {
+	return &ToleranceMatcher{
+		config: config,
+	}
+}
+
+func (tm *ToleranceMatcher) SetConfig(config MatcherConfig) {
+	tm.config = config
+}
+
+func (tm *ToleranceMatcher) Name() string {
+	return "tolerance"
+}
+
+// Match finds matching transactions whose amounts are within tolerance.
+// Bank transactions are indexed by date_type. A first pass pairs identical amounts
+// so exact matches are never stolen by near ones, then a second pass pairs each
+// remaining system transaction with the bank candidate closest in amount.
+// If two candidates are equally close, the system transaction stays unmatched.
+func (tm *ToleranceMatcher) Match(systemTxns, bankTxns []*transaction.Transaction) (*MatchResult, error) {
+	result := NewMatchResult(tm.Name())
+
+	bankTxnMap := make(map[string][]*transaction.Transaction)
+	for _, bankTxn := range bankTxns {
+		key := dateTypeKey(bankTxn) + sourceKey(bankTxn, tm.config)
+		bankTxnMap[key] = append(bankTxnMap[key], bankTxn)
+	}
+
+	matchedBankTxns := make(map[string]bool)
+	matchedSysTxns := make(map[*transaction.Transaction]bool)
+	ambiguousSysTxns := make(map[*transaction.Transaction]bool)
+
+	for _, exactOnly := range []bool{true, false} {
+		for _, sysTxn := range systemTxns {
+			if matchedSysTxns[sysTxn] || ambiguousSysTxns[sysTxn] {
+				continue
+			}
+
+			allowed := tm.allowedDifference(sysTxn)
+			var best *transaction.Transaction
+			var bestDiff domain.Money
+			count := 0
+			for _, bankTxn := range bankTxnMap[dateTypeKey(sysTxn)+sourceKey(sysTxn, tm.config)] {
+				if matchedBankTxns[bankTxn.ID] || bankTxn.AbsAmount().Currency != sysTxn.AbsAmount().Currency {
+					continue
+				}
+				diff := amountDifference(sysTxn.AbsAmount(), bankTxn.AbsAmount())
+				if exactOnly && !diff.IsZero() {
+					continue
+				}
+				if !exactOnly && diff.Cmp(allowed) > 0 {
+					continue
+				}
+				switch {
+				case best == nil || diff.Cmp(bestDiff) < 0:
+					best, bestDiff, count = bankTxn, diff, 1
+				case amountsEqual(diff, bestDiff):
+					count++
+				}
+			}
+
+			if count > 1 {
+				ambiguousSysTxns[sysTxn] = true
+				continue
+			}
+			if best == nil {
+				continue
+			}
+
+			pair := MatchPair{
+				SystemTransaction: sysTxn,
+				BankTransaction:   best,
+				ConfidenceScore:   tm.confidence(bestDiff, allowed),
+				AmountDiscrepancy: bestDiff,
+			}
+			result.Matched = append(result.Matched, pair)
+			matchedBankTxns[best.ID] = true
+			matchedSysTxns[sysTxn] = true
+		}
+	}
+
+	for _, sysTxn := range systemTxns {
+		if !matchedSysTxns[sysTxn] {
+			result.UnmatchedSystem = append(result.UnmatchedSystem, sysTxn)
+		}
+	}
+
+	for _, bankTxn := range bankTxns {
+		if !matchedBankTxns[bankTxn.ID] {
+			result.UnmatchedBank = append(result.UnmatchedBank, bankTxn)
+		}
+	}
+
+	if tm.config.FXRates != nil {
+		matchForeignCurrency(result, tm.config)
+	}
+
+	if tm.config.MatchSource {
+		result.FlagWrongBank()
+	}
+
+	result.Finalize()
+	return result, nil
+}
+
+// allowedDifference returns the largest amount gap accepted for a system transaction.
+// The absolute cap only applies to transactions in the cap's currency.
+func (tm *ToleranceMatcher) allowedDifference(sysTxn *transaction.Transaction) domain.Money {
+	allowed := sysTxn.AbsAmount().Percent(tm.config.AmountTolerancePct)
+	limit := tm.config.AmountToleranceAbs
+	if !limit.IsZero() && limit.Currency == allowed.Currency && allowed.Cmp(limit) > 0 {
+		allowed = limit
+	}
+	return allowed
+}
+
+// confidence scales from 100 for identical amounts down to toleranceConfidenceFloor
+// for a gap that uses the whole allowed difference.
+func (tm *ToleranceMatcher) confidence(diff, allowed domain.Money) float64 {
+	if diff.IsZero() {
+		return 100.0
+	}
+	if allowed.IsZero() {
+		return toleranceConfidenceFloor
+	}
+	ratio := math.Min(diff.Float64()/allowed.Float64(), 1.0)
+	return 100.0 - ratio*(100.0-toleranceConfidenceFloor)
+}
+
+// dateTypeKey creates a key like "2024-03-15_debit" for indexing without the amount.
+func dateTypeKey(txn *transaction.Transaction) string {
+	typeStr := "credit"
+	if txn.IsDebit() {
+		typeStr = "debit"
+	}
+	return txn.TransactionDate.Format("2006-01-02") + "_" + typeStr
+}
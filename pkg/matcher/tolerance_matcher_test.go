@@ -0,0 +1,175 @@
+package matcher
+
+import (
+	"testing"
+	"time"
+
+	"github.com/farhaan/amartha-reconcile-system/internal/domain"
+	"github.com/farhaan/amartha-reconcile-system/internal/domain/transaction"
+)
+
+func TestToleranceMatcher_Name(t *testing.T) {
+	matcher := NewToleranceMatcher(DefaultConfig())
+	if matcher.Name() != "tolerance" {
+		t.Errorf("Expected name 'tolerance', got %s", matcher.Name())
+	}
+}
+
+func TestToleranceMatcher_Match_WithinTolerance(t *testing.T) {
+	matcher := NewToleranceMatcher(MatcherConfig{AmountTolerancePct: 1.0})
+	date := time.Date(2024, 3, 15, 0, 0, 0, 0, time.UTC)
+
+	// Bank deducted a 6,500 transfer fee
+	systemTxns := []*transaction.Transaction{
+		createSystemTransaction("SYS001", "BCA", 1000000.00, domain.TransactionTypeCredit, date),
+	}
+
+	bankTxns := []*transaction.Transaction{
+		createBankTransaction("BANK001", "BCA", 993500.00, domain.TransactionTypeCredit, date),
+	}
+
+	result, err := matcher.Match(systemTxns, bankTxns)
+	if err != nil {
+		t.Fatalf("Match failed: %v", err)
+	}
+
+	if len(result.Matched) != 1 {
+		t.Fatalf("Expected 1 match, got %d", len(result.Matched))
+	}
+
+	match := result.Matched[0]
+	if !match.AmountDiscrepancy.Equal(money(6500.00)) {
+		t.Errorf("Expected discrepancy 6500.00, got %s", match.AmountDiscrepancy)
+	}
+
+	if match.ConfidenceScore >= 100.0 || match.ConfidenceScore < toleranceConfidenceFloor {
+		t.Errorf("Expected confidence between %.0f and 100, got %f", toleranceConfidenceFloor, match.ConfidenceScore)
+	}
+}
+
+func TestToleranceMatcher_Match_OutsideTolerance(t *testing.T) {
+	matcher := NewToleranceMatcher(MatcherConfig{AmountTolerancePct: 0.5})
+	date := time.Date(2024, 3, 15, 0, 0, 0, 0, time.UTC)
+
+	systemTxns := []*transaction.Transaction{
+		createSystemTransaction("SYS001", "BCA", 1000000.00, domain.TransactionTypeCredit, date),
+	}
+
+	bankTxns := []*transaction.Transaction{
+		createBankTransaction("BANK001", "BCA", 993500.00, domain.TransactionTypeCredit, date),
+	}
+
+	result, err := matcher.Match(systemTxns, bankTxns)
+	if err != nil {
+		t.Fatalf("Match failed: %v", err)
+	}
+
+	if len(result.Matched) != 0 {
+		t.Errorf("Expected 0 matches (outside tolerance), got %d", len(result.Matched))
+	}
+}
+
+func TestToleranceMatcher_Match_AbsoluteCap(t *testing.T) {
+	// 1% of 1,000,000 is 10,000 but the cap only allows 5,000
+	matcher := NewToleranceMatcher(MatcherConfig{AmountTolerancePct: 1.0, AmountToleranceAbs: money(5000.00)})
+	date := time.Date(2024, 3, 15, 0, 0, 0, 0, time.UTC)
+
+	systemTxns := []*transaction.Transaction{
+		createSystemTransaction("SYS001", "BCA", 1000000.00, domain.TransactionTypeCredit, date),
+	}
+
+	bankTxns := []*transaction.Transaction{
+		createBankTransaction("BANK001", "BCA", 993500.00, domain.TransactionTypeCredit, date),
+	}
+
+	result, err := matcher.Match(systemTxns, bankTxns)
+	if err != nil {
+		t.Fatalf("Match failed: %v", err)
+	}
+
+	if len(result.Matched) != 0 {
+		t.Errorf("Expected 0 matches (above absolute cap), got %d", len(result.Matched))
+	}
+}
+
+func TestToleranceMatcher_Match_PrefersExactAmount(t *testing.T) {
+	matcher := NewToleranceMatcher(MatcherConfig{AmountTolerancePct: 1.0})
+	date := time.Date(2024, 3, 15, 0, 0, 0, 0, time.UTC)
+
+	// SYS001 comes first and would accept BANK002, which is SYS002's exact match
+	systemTxns := []*transaction.Transaction{
+		createSystemTransaction("SYS001", "BCA", 1000.00, domain.TransactionTypeDebit, date),
+		createSystemTransaction("SYS002", "BCA", 995.00, domain.TransactionTypeDebit, date),
+	}
+
+	bankTxns := []*transaction.Transaction{
+		createBankTransaction("BANK002", "BCA", -995.00, domain.TransactionTypeDebit, date),
+		createBankTransaction("BANK001", "BCA", -1000.00, domain.TransactionTypeDebit, date),
+	}
+
+	result, err := matcher.Match(systemTxns, bankTxns)
+	if err != nil {
+		t.Fatalf("Match failed: %v", err)
+	}
+
+	if len(result.Matched) != 2 {
+		t.Fatalf("Expected 2 matches, got %d", len(result.Matched))
+	}
+
+	for _, match := range result.Matched {
+		if !match.AmountDiscrepancy.IsZero() {
+			t.Errorf("Expected exact pairing, %s ↔ %s has discrepancy %s",
+				match.SystemTransaction.ID, match.BankTransaction.ID, match.AmountDiscrepancy)
+		}
+		if match.ConfidenceScore != 100.0 {
+			t.Errorf("Expected confidence 100 for exact amount, got %f", match.ConfidenceScore)
+		}
+	}
+}
+
+func TestToleranceMatcher_Match_EquallyCloseIsAmbiguous(t *testing.T) {
+	matcher := NewToleranceMatcher(MatcherConfig{AmountTolerancePct: 1.0})
+	date := time.Date(2024, 3, 15, 0, 0, 0, 0, time.UTC)
+
+	systemTxns := []*transaction.Transaction{
+		createSystemTransaction("SYS001", "BCA", 1000.00, domain.TransactionTypeDebit, date),
+	}
+
+	bankTxns := []*transaction.Transaction{
+		createBankTransaction("BANK001", "BCA", -995.00, domain.TransactionTypeDebit, date),
+		createBankTransaction("BANK002", "BCA", -1005.00, domain.TransactionTypeDebit, date),
+	}
+
+	result, err := matcher.Match(systemTxns, bankTxns)
+	if err != nil {
+		t.Fatalf("Match failed: %v", err)
+	}
+
+	if len(result.Matched) != 0 {
+		t.Errorf("Expected 0 matches (ambiguous), got %d", len(result.Matched))
+	}
+}
+
+func TestToleranceMatcher_Match_DefaultConfigIsExact(t *testing.T) {
+	matcher := NewToleranceMatcher(DefaultConfig())
+	date := time.Date(2024, 3, 15, 0, 0, 0, 0, time.UTC)
+
+	systemTxns := []*transaction.Transaction{
+		createSystemTransaction("SYS001", "BCA", 150.50, domain.TransactionTypeDebit, date),
+		createSystemTransaction("SYS002", "BCA", 150.00, domain.TransactionTypeDebit, date),
+	}
+
+	bankTxns := []*transaction.Transaction{
+		createBankTransaction("BANK001", "BCA", -150.50, domain.TransactionTypeDebit, date),
+		createBankTransaction("BANK002", "BCA", -149.00, domain.TransactionTypeDebit, date),
+	}
+
+	result, err := matcher.Match(systemTxns, bankTxns)
+	if err != nil {
+		t.Fatalf("Match failed: %v", err)
+	}
+
+	if len(result.Matched) != 1 {
+		t.Errorf("Expected 1 match with zero tolerance, got %d", len(result.Matched))
+	}
+}
@@ -0,0 +1,84 @@
+package report
+
+import (
+	encodingcsv "encoding/csv"
+	"fmt"
+	"io"
+	"strconv"
+	"time"
+
+	"github.com/farhaan/amartha-reconcile-system/internal/domain/transaction"
+	"github.com/farhaan/amartha-reconcile-system/pkg/matcher"
+)
+
+// csvHeader is the header of the CSV report
+var csvHeader = []string{
+	"status", "match_id", "side", "id", "source", "type", "date",
+	"amount", "currency", "confidence", "amount_discrepancy", "references",
+}
+
+// CSVRenderer writes one row per transaction with its match status, for loading into
+// spreadsheets or databases. The exception files of WriteExceptionCSVs hold more detail.
+type CSVRenderer struct{}
+
+// NewCSVRenderer creates a CSV renderer
+func NewCSVRenderer() *CSVRenderer {
+	return &CSVRenderer{}
+}
+
+// Name returns the name of the format
+func (r *CSVRenderer) Name() string {
+	return "csv"
+}
+
+// Render writes matched pairs, grouped matches, then unmatched system and bank transactions.
+// A match's discrepancy is on its first row only, so the column adds up.
+func (r *CSVRenderer) Render(w io.Writer, result *matcher.MatchResult, run *Run) error {
+	writer := encodingcsv.NewWriter(w)
+	writer.Write(csvHeader)
+
+	write := func(status, matchID string, txn *transaction.Transaction, confidence, discrepancy string) {
+		writer.Write([]string{
+			status,
+			matchID,
+			string(txn.SourceType),
+			txn.ID,
+			txn.Source,
+			string(txn.Type),
+			txn.TransactionDate.Format(time.RFC3339),
+			txn.Amount.String(),
+			string(txn.Amount.Currency),
+			confidence,
+			discrepancy,
+			references(txn),
+		})
+	}
+
+	for i, pair := range result.Matched {
+		matchID := fmt.Sprintf("M%d", i+1)
+		confidence := strconv.FormatFloat(pair.ConfidenceScore, 'f', 1, 64)
+		write("matched", matchID, pair.SystemTransaction, confidence, pair.AmountDiscrepancy.String())
+		write("matched", matchID, pair.BankTransaction, confidence, "")
+	}
+	for i, group := range result.Grouped {
+		matchID := fmt.Sprintf("G%d", i+1)
+		confidence := strconv.FormatFloat(group.ConfidenceScore, 'f', 1, 64)
+		discrepancy := group.AmountDiscrepancy.String()
+		for _, txn := range append(append([]*transaction.Transaction{}, group.SystemTransactions...), group.BankTransactions...) {
+			write("grouped", matchID, txn, confidence, discrepancy)
+			discrepancy = ""
+		}
+	}
+	for _, txn := range result.UnmatchedSystem {
+		write("unmatched", "", txn, "", "")
+	}
+	for _, txn := range result.UnmatchedBank {
+		write("unmatched", "", txn, "", "")
+	}
+
+	writer.Flush()
+	if err := writer.Error(); err != nil {
+		return fmt.Errorf("failed to write CSV report: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,211 @@
+package report
+
+import (
+	encodingcsv "encoding/csv"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strconv"
+	"time"
+
+	"github.com/farhaan/amartha-reconcile-system/internal/domain/transaction"
+	"github.com/farhaan/amartha-reconcile-system/internal/infrastructure/xlsx"
+	"github.com/farhaan/amartha-reconcile-system/pkg/matcher"
+)
+
+// exceptionTable is one exception export: a CSV file, or a sheet of the workbook
+type exceptionTable struct {
+	file    string // CSV file name
+	sheet   string // Worksheet name
+	rows    [][]string
+	numbers []int // Amount columns, written as numbers in the workbook
+}
+
+// transactionFields are the columns every exported transaction has, before its raw data
+var transactionFields = []string{
+	"id", "side", "source", "type", "date", "amount", "currency",
+	"job_id", "file_id", "matched", "created_at", "updated_at",
+}
+
+// amountField is the position of "amount" in transactionFields
+const amountField = 5
+
+// transactionColumns lays out transactions as columns: the fields of every transaction,
+// then one "raw_" column per RawData key and one "normalized_" column per NormalizedData
+// key found in any of them
+type transactionColumns struct {
+	raw        []string
+	normalized []string
+}
+
+func newTransactionColumns(txns []*transaction.Transaction) transactionColumns {
+	raw, normalized := make(map[string]bool), make(map[string]bool)
+	for _, txn := range txns {
+		for key := range txn.RawData {
+			raw[key] = true
+		}
+		for key := range txn.NormalizedData {
+			normalized[key] = true
+		}
+	}
+	return transactionColumns{raw: sortedKeys(raw), normalized: sortedKeys(normalized)}
+}
+
+func (c transactionColumns) width() int {
+	return len(transactionFields) + len(c.raw) + len(c.normalized)
+}
+
+func (c transactionColumns) header(prefix string) []string {
+	header := make([]string, 0, c.width())
+	for _, field := range transactionFields {
+		header = append(header, prefix+field)
+	}
+	for _, key := range c.raw {
+		header = append(header, prefix+"raw_"+key)
+	}
+	for _, key := range c.normalized {
+		header = append(header, prefix+"normalized_"+key)
+	}
+	return header
+}
+
+// row returns the cells of a transaction, or empty cells for nil
+func (c transactionColumns) row(txn *transaction.Transaction) []string {
+	if txn == nil {
+		return make([]string, c.width())
+	}
+
+	row := []string{
+		txn.ID,
+		string(txn.SourceType),
+		txn.Source,
+		string(txn.Type),
+		txn.TransactionDate.Format(time.RFC3339),
+		txn.Amount.String(),
+		string(txn.Amount.Currency),
+		txn.JobID,
+		txn.FileID,
+		strconv.FormatBool(txn.Matched),
+		txn.CreatedAt.Format(time.RFC3339),
+		txn.UpdatedAt.Format(time.RFC3339),
+	}
+	for _, key := range c.raw {
+		row = append(row, formatValue(txn.RawData[key]))
+	}
+	for _, key := range c.normalized {
+		row = append(row, formatValue(txn.NormalizedData[key]))
+	}
+	return row
+}
+
+// pairTable builds the matched or discrepancy export from rows
+func pairTable(file, sheet string, rows []pairRow) exceptionTable {
+	systemTxns := make([]*transaction.Transaction, 0, len(rows))
+	bankTxns := make([]*transaction.Transaction, 0, len(rows))
+	for _, row := range rows {
+		systemTxns = append(systemTxns, row.System)
+		bankTxns = append(bankTxns, row.Bank)
+	}
+	systemColumns, bankColumns := newTransactionColumns(systemTxns), newTransactionColumns(bankTxns)
+
+	header := []string{"match_id", "confidence", "amount_discrepancy", "fx_discrepancy", "day_offset"}
+	header = append(header, systemColumns.header("system_")...)
+	header = append(header, bankColumns.header("bank_")...)
+
+	table := exceptionTable{
+		file:    file,
+		sheet:   sheet,
+		rows:    [][]string{header},
+		numbers: []int{1, 2, 3, 4, 5 + amountField, 5 + systemColumns.width() + amountField},
+	}
+	for _, row := range rows {
+		cells := []string{
+			row.MatchID,
+			strconv.FormatFloat(row.Confidence, 'f', 1, 64),
+			row.AmountDiscrepancy.String(),
+			row.FXDiscrepancy.String(),
+			strconv.Itoa(row.DayOffset),
+		}
+		cells = append(cells, systemColumns.row(row.System)...)
+		cells = append(cells, bankColumns.row(row.Bank)...)
+		table.rows = append(table.rows, cells)
+	}
+	return table
+}
+
+// transactionTable builds an unmatched export
+func transactionTable(file, sheet string, txns []*transaction.Transaction) exceptionTable {
+	columns := newTransactionColumns(txns)
+	table := exceptionTable{
+		file:    file,
+		sheet:   sheet,
+		rows:    [][]string{columns.header("")},
+		numbers: []int{amountField},
+	}
+	for _, txn := range txns {
+		table.rows = append(table.rows, columns.row(txn))
+	}
+	return table
+}
+
+// exceptionTables returns the matched, unmatched and discrepancy exports of a result
+func exceptionTables(result *matcher.MatchResult) []exceptionTable {
+	matched := pairRows(result)
+	return []exceptionTable{
+		pairTable("matched.csv", "Matched", matched),
+		transactionTable("unmatched_system.csv", "Unmatched System", result.UnmatchedSystem),
+		transactionTable("unmatched_bank.csv", "Unmatched Bank", result.UnmatchedBank),
+		pairTable("discrepancies.csv", "Discrepancies", discrepantRows(matched)),
+	}
+}
+
+// WriteExceptionCSVs writes matched.csv, unmatched_system.csv, unmatched_bank.csv and
+// discrepancies.csv to dir, with every transaction field and its raw data
+func WriteExceptionCSVs(dir string, result *matcher.MatchResult) error {
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		return fmt.Errorf("failed to create directory %s: %w", dir, err)
+	}
+
+	for _, table := range exceptionTables(result) {
+		filePath := filepath.Join(dir, table.file)
+		file, err := os.Create(filePath)
+		if err != nil {
+			return fmt.Errorf("failed to create file %s: %w", filePath, err)
+		}
+
+		writer := encodingcsv.NewWriter(file)
+		writer.WriteAll(table.rows)
+		if err := writer.Error(); err != nil {
+			file.Close()
+			return fmt.Errorf("failed to write %s: %w", filePath, err)
+		}
+		if err := file.Close(); err != nil {
+			return fmt.Errorf("failed to write %s: %w", filePath, err)
+		}
+	}
+	return nil
+}
+
+// WriteExceptionWorkbook writes the exception files as sheets of one XLSX workbook
+func WriteExceptionWorkbook(filePath string, result *matcher.MatchResult) error {
+	tables := exceptionTables(result)
+	sheets := make([]xlsx.Sheet, 0, len(tables))
+	for _, table := range tables {
+		sheets = append(sheets, xlsx.Sheet{Name: table.sheet, Rows: table.rows, Numbers: table.numbers})
+	}
+	return xlsx.Write(filePath, sheets)
+}
+
+// formatValue formats a raw data value for a cell
+func formatValue(v any) string {
+	switch value := v.(type) {
+	case nil:
+		return ""
+	case string:
+		return value
+	case time.Time:
+		return value.Format(time.RFC3339)
+	default:
+		return fmt.Sprint(value)
+	}
+}
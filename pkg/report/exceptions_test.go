@@ -0,0 +1,103 @@
+package report
+
+import (
+	encodingcsv "encoding/csv"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/farhaan/amartha-reconcile-system/internal/domain"
+	"github.com/farhaan/amartha-reconcile-system/internal/domain/transaction"
+	"github.com/farhaan/amartha-reconcile-system/internal/infrastructure/xlsx"
+	"github.com/farhaan/amartha-reconcile-system/pkg/matcher"
+)
+
+func TestExceptionTables(t *testing.T) {
+	date := time.Date(2024, 3, 15, 0, 0, 0, 0, time.UTC)
+	newTxn := func(id string, sourceType domain.SourceType, minor int64) *transaction.Transaction {
+		txn := transaction.NewTransaction("job", "file", sourceType, date, domain.NewMoney(minor, "IDR"), domain.TransactionTypeCredit, "BCA")
+		txn.ID = id
+		txn.RawData["description"] = "transfer " + id
+		return txn
+	}
+
+	result := matcher.NewMatchResult("split")
+	result.Matched = append(result.Matched,
+		matcher.MatchPair{SystemTransaction: newTxn("SYS001", domain.SourceTypeSystem, 10000), BankTransaction: newTxn("BANK001", domain.SourceTypeBank, 10000), ConfidenceScore: 100},
+		matcher.MatchPair{SystemTransaction: newTxn("SYS002", domain.SourceTypeSystem, 15050), BankTransaction: newTxn("BANK002", domain.SourceTypeBank, 15000), ConfidenceScore: 90, AmountDiscrepancy: domain.NewMoney(50, "IDR")},
+	)
+	result.Grouped = append(result.Grouped, matcher.MatchGroup{
+		SystemTransactions: []*transaction.Transaction{newTxn("SYS003", domain.SourceTypeSystem, 1000), newTxn("SYS004", domain.SourceTypeSystem, 2000)},
+		BankTransactions:   []*transaction.Transaction{newTxn("BANK003", domain.SourceTypeBank, 3010)},
+		ConfidenceScore:    95,
+		AmountDiscrepancy:  domain.NewMoney(10, "IDR"),
+	})
+	result.UnmatchedSystem = append(result.UnmatchedSystem, newTxn("SYS005", domain.SourceTypeSystem, 500))
+	result.Finalize()
+
+	tables := exceptionTables(result)
+	rowCounts := map[string]int{"matched.csv": 4, "unmatched_system.csv": 1, "unmatched_bank.csv": 0, "discrepancies.csv": 3}
+	for _, table := range tables {
+		if got := len(table.rows) - 1; got != rowCounts[table.file] {
+			t.Errorf("%s: expected %d rows, got %d", table.file, rowCounts[table.file], got)
+		}
+	}
+
+	dir := t.TempDir()
+	if err := WriteExceptionCSVs(dir, result); err != nil {
+		t.Fatalf("WriteExceptionCSVs failed: %v", err)
+	}
+
+	file, err := os.Open(filepath.Join(dir, "matched.csv"))
+	if err != nil {
+		t.Fatalf("failed to open matched.csv: %v", err)
+	}
+	defer file.Close()
+	records, err := encodingcsv.NewReader(file).ReadAll()
+	if err != nil {
+		t.Fatalf("failed to read matched.csv: %v", err)
+	}
+
+	column := make(map[string]int)
+	for i, name := range records[0] {
+		column[name] = i
+	}
+	for _, name := range []string{"match_id", "system_id", "system_raw_description", "bank_amount", "bank_raw_description"} {
+		if _, ok := column[name]; !ok {
+			t.Errorf("Expected column %s in %v", name, records[0])
+		}
+	}
+
+	// Both members of the group share its ID and its single bank line
+	for _, record := range records[3:] {
+		if record[column["match_id"]] != "G1" || record[column["bank_id"]] != "BANK003" {
+			t.Errorf("Expected group G1 against BANK003, got %s against %s", record[column["match_id"]], record[column["bank_id"]])
+		}
+	}
+	// The group's discrepancy is counted once, so the column sums to the total
+	if first, second := records[3][column["amount_discrepancy"]], records[4][column["amount_discrepancy"]]; first != "0.10" || second != "0.00" {
+		t.Errorf("Expected the group discrepancy 0.10 on its first row only, got %s and %s", first, second)
+	}
+	if got := records[2][column["system_raw_description"]]; got != "transfer SYS002" {
+		t.Errorf("Expected raw description of SYS002, got %q", got)
+	}
+
+	workbook := filepath.Join(dir, "exceptions.xlsx")
+	if err := WriteExceptionWorkbook(workbook, result); err != nil {
+		t.Fatalf("WriteExceptionWorkbook failed: %v", err)
+	}
+	reader, err := xlsx.Open(workbook, "Unmatched System")
+	if err != nil {
+		t.Fatalf("failed to open sheet: %v", err)
+	}
+	defer reader.Close()
+	reader.Read() // header
+	record, err := reader.Read()
+	if err != nil {
+		t.Fatalf("Read failed: %v", err)
+	}
+	if record[0] != "SYS005" || record[amountField] != "5.00" {
+		t.Errorf("Expected SYS005 at 5.00, got %s at %s", record[0], record[amountField])
+	}
+}
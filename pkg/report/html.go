@@ -0,0 +1,294 @@
+package report
+
+import (
+	"fmt"
+	"html/template"
+	"io"
+	"time"
+
+	"github.com/farhaan/amartha-reconcile-system/internal/domain/statement"
+	"github.com/farhaan/amartha-reconcile-system/internal/domain/transaction"
+	"github.com/farhaan/amartha-reconcile-system/pkg/matcher"
+)
+
+// Size of the match rate chart, in SVG units
+const (
+	chartWidth  = 640
+	chartHeight = 200
+	chartMargin = 30
+)
+
+// htmlReport is everything the HTML template shows
+type htmlReport struct {
+	GeneratedAt   time.Time
+	Start, End    string
+	Result        *matcher.MatchResult
+	Unmatched     int
+	Rejected      int
+	Chart         []chartBar
+	Statements    []*statement.Statement
+	Matched       []pairRow
+	Discrepancies []pairRow
+	System        []*transaction.Transaction
+	Bank          []*transaction.Transaction
+}
+
+// breakdownTable is one breakdown of the result with the name of its key column
+type breakdownTable struct {
+	Key  string
+	Rows []matcher.Breakdown
+}
+
+// chartBar is one day of the match rate chart
+type chartBar struct {
+	X, Y, Width, Height float64
+	LabelX              float64
+	Label               string
+	Rate                float64
+}
+
+// HTMLRenderer writes one HTML page with its styles, scripts and chart inlined,
+// so it can be mailed and archived as a single file
+type HTMLRenderer struct{}
+
+// NewHTMLRenderer creates an HTML renderer
+func NewHTMLRenderer() *HTMLRenderer {
+	return &HTMLRenderer{}
+}
+
+// Name returns the name of the format
+func (r *HTMLRenderer) Name() string {
+	return "html"
+}
+
+// Render writes the report as an HTML page
+func (r *HTMLRenderer) Render(w io.Writer, result *matcher.MatchResult, run *Run) error {
+	matched := pairRows(result)
+
+	report := &htmlReport{
+		GeneratedAt:   run.GeneratedAt,
+		Start:         run.Start.Format("2006-01-02"),
+		End:           run.End.Format("2006-01-02"),
+		Result:        result,
+		Unmatched:     len(result.UnmatchedSystem) + len(result.UnmatchedBank),
+		Rejected:      len(run.Rejects),
+		Chart:         chartBars(result.ByDate),
+		Statements:    run.Statements,
+		Matched:       matched,
+		Discrepancies: discrepantRows(matched),
+		System:        result.UnmatchedSystem,
+		Bank:          result.UnmatchedBank,
+	}
+
+	if err := htmlTemplate.Execute(w, report); err != nil {
+		return fmt.Errorf("failed to render HTML report: %w", err)
+	}
+	return nil
+}
+
+// chartBars lays out one bar per day, its height the day's match rate
+func chartBars(days []matcher.Breakdown) []chartBar {
+	if len(days) == 0 {
+		return nil
+	}
+
+	plotWidth := float64(chartWidth - 2*chartMargin)
+	plotHeight := float64(chartHeight - 2*chartMargin)
+	slot := plotWidth / float64(len(days))
+
+	bars := make([]chartBar, 0, len(days))
+	for i, day := range days {
+		rate := day.MatchRate
+		height := plotHeight * rate / 100
+		x := chartMargin + float64(i)*slot + slot*0.1
+		bars = append(bars, chartBar{
+			X:      x,
+			Y:      chartMargin + plotHeight - height,
+			Width:  slot * 0.8,
+			Height: height,
+			LabelX: x + slot*0.4,
+			Label:  day.Key,
+			Rate:   rate,
+		})
+	}
+	return bars
+}
+
+var htmlTemplate = template.Must(template.New("report").Funcs(template.FuncMap{
+	"chartWidth":  func() int { return chartWidth },
+	"chartHeight": func() int { return chartHeight },
+	"chartTop":    func() int { return chartMargin },
+	"chartBase":   func() int { return chartHeight - chartMargin },
+	"date":        func(t time.Time) string { return t.Format("2006-01-02") },
+	"ref":         references,
+	"breakdownTable": func(key string, rows []matcher.Breakdown) breakdownTable {
+		return breakdownTable{Key: key, Rows: rows}
+	},
+	"matched":     func(b matcher.Breakdown) int { return b.MatchedSystem + b.MatchedBank },
+	"unconverted": formatAmounts,
+}).Parse(htmlSource))
+
+const htmlSource = `<!DOCTYPE html>
+<html lang="en">
+<head>
+<meta charset="utf-8">
+<title>Reconciliation Report {{.Start}} to {{.End}}</title>
+<style>
+body { font-family: -apple-system, "Segoe UI", Helvetica, Arial, sans-serif; margin: 2em; color: #222; }
+h1 { margin-bottom: 0; }
+h2 { margin-top: 2em; border-bottom: 1px solid #ccc; }
+.meta { color: #666; }
+.cards { display: flex; flex-wrap: wrap; gap: 1em; margin-top: 1em; }
+.card { border: 1px solid #ddd; border-radius: 4px; padding: 0.6em 1em; min-width: 9em; }
+.card b { display: block; font-size: 1.4em; }
+table { border-collapse: collapse; width: 100%; font-size: 0.9em; margin-top: 0.5em; }
+th, td { border: 1px solid #ddd; padding: 0.3em 0.6em; text-align: left; }
+th { background: #f4f4f4; cursor: pointer; user-select: none; }
+th.asc::after { content: " \25B2"; }
+th.desc::after { content: " \25BC"; }
+td.num { text-align: right; font-variant-numeric: tabular-nums; }
+tr.bad td { background: #fdecea; }
+input.filter { margin-top: 0.5em; padding: 0.3em; width: 20em; }
+svg text { font-size: 10px; fill: #444; }
+svg rect.bar { fill: #4a90d9; }
+</style>
+</head>
+<body>
+<h1>Reconciliation Report</h1>
+<p class="meta">Period {{.Start}} to {{.End}} &middot; Matcher {{.Result.AlgorithmUsed}} &middot; Generated {{.GeneratedAt.Format "2006-01-02 15:04:05"}} UTC</p>
+
+<div class="cards">
+<div class="card">System transactions<b>{{.Result.TotalSystemTxns}}</b></div>
+<div class="card">Bank transactions<b>{{.Result.TotalBankTxns}}</b></div>
+<div class="card">Matched<b>{{.Result.TotalMatched}} ({{printf "%.1f" .Result.MatchRate}}%)</b></div>
+<div class="card">Unmatched<b>{{.Unmatched}}</b></div>
+<div class="card">Total discrepancy<b>{{.Result.TotalDiscrepancy}} {{.Result.BaseCurrency}}</b></div>
+<div class="card">Matched pair discrepancies<b>{{.Result.MatchedDiscrepancy}} {{.Result.BaseCurrency}}</b></div>
+<div class="card">Unmatched system debits / credits<b>{{.Result.UnmatchedSystemDebits}} / {{.Result.UnmatchedSystemCredits}}</b></div>
+<div class="card">Unmatched bank debits / credits<b>{{.Result.UnmatchedBankDebits}} / {{.Result.UnmatchedBankCredits}}</b></div>
+<div class="card">Net difference (system - bank)<b>{{.Result.NetDifference}} {{.Result.BaseCurrency}}</b></div>
+{{- if not .Result.TotalFXDiscrepancy.IsZero}}
+<div class="card">FX discrepancy<b>{{.Result.TotalFXDiscrepancy}} {{.Result.BaseCurrency}}</b></div>
+{{- end}}
+{{- range $currency, $amount := .Result.UnconvertedDiscrepancy}}
+<div class="card">Unconverted<b>{{$amount}} {{$currency}}</b></div>
+{{- end}}
+<div class="card">Rejected rows<b>{{.Rejected}}</b></div>
+</div>
+
+<h2>By Bank</h2>
+{{template "breakdown" (breakdownTable "Bank" .Result.BySource)}}
+
+<h2>By Type</h2>
+{{template "breakdown" (breakdownTable "Type" .Result.ByType)}}
+
+<h2>Match Rate per Day</h2>
+{{- if .Chart}}
+<svg xmlns="http://www.w3.org/2000/svg" width="{{chartWidth}}" height="{{chartHeight}}" viewBox="0 0 {{chartWidth}} {{chartHeight}}" role="img" aria-label="Match rate per day">
+<line x1="{{chartTop}}" y1="{{chartTop}}" x2="{{chartWidth}}" y2="{{chartTop}}" stroke="#eee"/>
+<line x1="{{chartTop}}" y1="{{chartBase}}" x2="{{chartWidth}}" y2="{{chartBase}}" stroke="#999"/>
+<text x="0" y="{{chartTop}}" dy="4">100%</text>
+<text x="8" y="{{chartBase}}">0%</text>
+{{- range .Chart}}
+<rect class="bar" x="{{printf "%.1f" .X}}" y="{{printf "%.1f" .Y}}" width="{{printf "%.1f" .Width}}" height="{{printf "%.1f" .Height}}"><title>{{.Label}}: {{printf "%.1f" .Rate}}%</title></rect>
+<text x="{{printf "%.1f" .LabelX}}" y="{{printf "%.1f" .Y}}" dy="-3" text-anchor="middle">{{printf "%.0f" .Rate}}%</text>
+<text x="{{printf "%.1f" .LabelX}}" y="{{chartBase}}" dy="12" text-anchor="middle">{{.Label}}</text>
+{{- end}}
+</svg>
+{{- else}}
+<p>No transactions in the period.</p>
+{{- end}}
+{{template "breakdown" (breakdownTable "Date" .Result.ByDate)}}
+
+{{- if .Statements}}
+<h2>Statement Balance Checks</h2>
+<table class="sortable">
+<thead><tr><th>Bank</th><th>Account</th><th>Status</th><th>Opening</th><th>Closing</th><th>Difference</th><th>Reason</th></tr></thead>
+<tbody>
+{{- range .Statements}}
+<tr{{if eq .Check.Status "MISMATCH"}} class="bad"{{end}}><td>{{.Source}}</td><td>{{.AccountNumber}}</td><td>{{.Check.Status}}</td><td class="num">{{with .OpeningBalance}}{{.}}{{end}}</td><td class="num">{{with .ClosingBalance}}{{.}}{{end}}</td><td class="num">{{.Check.Difference}}</td><td>{{.Check.Reason}}</td></tr>
+{{- end}}
+</tbody>
+</table>
+{{- end}}
+
+{{- define "breakdown"}}
+<table class="sortable">
+<thead><tr><th>{{.Key}}</th><th>System</th><th>Bank lines</th><th>Matched</th><th>Unmatched system</th><th>Unmatched bank</th><th>Unmatched system amount</th><th>Unmatched bank amount</th><th>Net difference</th><th>Unconverted</th><th>Match rate</th></tr></thead>
+<tbody>
+{{- range .Rows}}
+<tr><td>{{.Key}}</td><td class="num">{{.SystemTxns}}</td><td class="num">{{.BankTxns}}</td><td class="num">{{matched .}}</td><td class="num">{{.UnmatchedSystem}}</td><td class="num">{{.UnmatchedBank}}</td><td class="num">{{.UnmatchedSystemAmount}}</td><td class="num">{{.UnmatchedBankAmount}}</td><td class="num">{{.NetDifference}}</td><td class="num">{{unconverted .UnconvertedAmount}}</td><td class="num">{{printf "%.1f" .MatchRate}}%</td></tr>
+{{- end}}
+</tbody>
+</table>
+{{- end}}
+
+{{- define "pairs"}}
+<input class="filter" type="search" placeholder="Filter...">
+<table class="sortable">
+<thead><tr><th>Match</th><th>System ID</th><th>System date</th><th>System amount</th><th>Bank ID</th><th>Bank date</th><th>Bank amount</th><th>Bank</th><th>Discrepancy</th><th>FX difference</th><th>Confidence</th></tr></thead>
+<tbody>
+{{- range .}}
+<tr><td>{{.MatchID}}</td><td>{{.System.ID}}</td><td>{{date .System.TransactionDate}}</td><td class="num">{{.System.Amount}} {{.System.Amount.Currency}}</td><td>{{.Bank.ID}}</td><td>{{date .Bank.TransactionDate}}</td><td class="num">{{.Bank.Amount}} {{.Bank.Amount.Currency}}</td><td>{{.Bank.Source}}</td><td class="num">{{.AmountDiscrepancy}}</td><td class="num">{{.FXDiscrepancy}}</td><td class="num">{{printf "%.1f" .Confidence}}</td></tr>
+{{- end}}
+</tbody>
+</table>
+{{- end}}
+
+{{- define "transactions"}}
+<input class="filter" type="search" placeholder="Filter...">
+<table class="sortable">
+<thead><tr><th>ID</th><th>Bank</th><th>Type</th><th>Date</th><th>Amount</th><th>Reference</th></tr></thead>
+<tbody>
+{{- range .}}
+<tr><td>{{.ID}}</td><td>{{.Source}}</td><td>{{.Type}}</td><td>{{date .TransactionDate}}</td><td class="num">{{.Amount}} {{.Amount.Currency}}</td><td>{{ref .}}</td></tr>
+{{- end}}
+</tbody>
+</table>
+{{- end}}
+
+<h2>Matches with Discrepancies ({{len .Discrepancies}})</h2>
+{{template "pairs" .Discrepancies}}
+
+<h2>Unmatched System Transactions ({{len .System}})</h2>
+{{template "transactions" .System}}
+
+<h2>Unmatched Bank Transactions ({{len .Bank}})</h2>
+{{template "transactions" .Bank}}
+
+<h2>All Matches ({{len .Matched}})</h2>
+{{template "pairs" .Matched}}
+
+<script>
+// Click a header to sort its table, type in the box above a table to filter its rows
+document.querySelectorAll("table.sortable").forEach(function (table) {
+  table.querySelectorAll("th").forEach(function (th, col) {
+    th.addEventListener("click", function () {
+      var asc = !th.classList.contains("asc");
+      table.querySelectorAll("th").forEach(function (h) { h.classList.remove("asc", "desc"); });
+      th.classList.add(asc ? "asc" : "desc");
+      var body = table.tBodies[0];
+      var rows = Array.prototype.slice.call(body.rows);
+      rows.sort(function (a, b) {
+        var x = a.cells[col].textContent.trim(), y = b.cells[col].textContent.trim();
+        var nx = parseFloat(x), ny = parseFloat(y);
+        var cmp = (!isNaN(nx) && !isNaN(ny) && /^-?[0-9.]+( |%|$)/.test(x)) ? nx - ny : x.localeCompare(y);
+        return asc ? cmp : -cmp;
+      });
+      rows.forEach(function (row) { body.appendChild(row); });
+    });
+  });
+});
+document.querySelectorAll("input.filter").forEach(function (input) {
+  var table = input.nextElementSibling;
+  input.addEventListener("input", function () {
+    var needle = input.value.toLowerCase();
+    Array.prototype.forEach.call(table.tBodies[0].rows, function (row) {
+      row.style.display = row.textContent.toLowerCase().indexOf(needle) >= 0 ? "" : "none";
+    });
+  });
+});
+</script>
+</body>
+</html>
+`
@@ -0,0 +1,56 @@
+package report
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/farhaan/amartha-reconcile-system/internal/domain"
+	"github.com/farhaan/amartha-reconcile-system/internal/domain/transaction"
+	"github.com/farhaan/amartha-reconcile-system/pkg/matcher"
+)
+
+func TestHTMLRenderer(t *testing.T) {
+	day1 := time.Date(2024, 3, 15, 0, 0, 0, 0, time.UTC)
+	day2 := day1.AddDate(0, 0, 1)
+	newTxn := func(id, source string, sourceType domain.SourceType, date time.Time) *transaction.Transaction {
+		txn := transaction.NewTransaction("job", "file", sourceType, date, domain.NewMoney(10000, "IDR"), domain.TransactionTypeCredit, source)
+		txn.ID = id
+		return txn
+	}
+
+	result := matcher.NewMatchResult("exact")
+	result.Matched = append(result.Matched, matcher.MatchPair{
+		SystemTransaction: newTxn("SYS001", "BCA", domain.SourceTypeSystem, day1),
+		BankTransaction:   newTxn("BANK001", "BCA", domain.SourceTypeBank, day1),
+		ConfidenceScore:   100,
+	})
+	result.UnmatchedBank = append(result.UnmatchedBank, newTxn("<script>alert(1)</script>", "MANDIRI", domain.SourceTypeBank, day2))
+	result.Finalize()
+
+	var buf bytes.Buffer
+	if err := NewHTMLRenderer().Render(&buf, result, &Run{Start: day1, End: day2}); err != nil {
+		t.Fatalf("Render failed: %v", err)
+	}
+	page := buf.String()
+
+	// One file: nothing is loaded from elsewhere
+	for _, external := range []string{"src=", "<link", "@import", "url("} {
+		if strings.Contains(page, external) {
+			t.Errorf("Expected no external assets, found %q", external)
+		}
+	}
+
+	if strings.Contains(page, "<script>alert(1)</script>") {
+		t.Error("Expected transaction IDs to be escaped")
+	}
+	if !strings.Contains(page, "&lt;script&gt;alert(1)&lt;/script&gt;") {
+		t.Error("Expected the unmatched bank line in the report")
+	}
+
+	// One bar per day: all of the 15th matched, nothing of the 16th
+	if !strings.Contains(page, "<title>2024-03-15: 100.0%</title>") || !strings.Contains(page, "<title>2024-03-16: 0.0%</title>") {
+		t.Error("Expected a match rate bar for each day")
+	}
+}
@@ -0,0 +1,349 @@
+package report
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"sort"
+	"time"
+
+	"github.com/farhaan/amartha-reconcile-system/internal/domain"
+	"github.com/farhaan/amartha-reconcile-system/internal/domain/statement"
+	"github.com/farhaan/amartha-reconcile-system/internal/domain/transaction"
+	"github.com/farhaan/amartha-reconcile-system/internal/infrastructure/input"
+	"github.com/farhaan/amartha-reconcile-system/pkg/matcher"
+)
+
+// SchemaVersion is bumped whenever a field of the JSON report is renamed, removed or
+// changes meaning. Adding fields does not change it.
+const SchemaVersion = 1
+
+// jsonReport is the JSON form of a reconciliation run. Amounts are decimal strings in
+// major units, e.g. "-150.50", next to the currency they are in.
+type jsonReport struct {
+	SchemaVersion   int               `json:"schema_version"`
+	GeneratedAt     time.Time         `json:"generated_at"`
+	Algorithm       string            `json:"algorithm"`
+	Period          jsonPeriod        `json:"period"`
+	Inputs          jsonInputs        `json:"inputs"`
+	Summary         jsonSummary       `json:"summary"`
+	Breakdowns      jsonBreakdowns    `json:"breakdowns"`
+	Matched         []jsonPair        `json:"matched"`
+	Grouped         []jsonGroup       `json:"grouped"`
+	UnmatchedSystem []jsonTransaction `json:"unmatched_system"`
+	UnmatchedBank   []jsonTransaction `json:"unmatched_bank"`
+	WrongBank       []jsonPair        `json:"wrong_bank"`
+	Statements      []jsonStatement   `json:"statements"`
+	Rejects         []input.Reject    `json:"rejects"`
+}
+
+type jsonPeriod struct {
+	Start string `json:"start"` // YYYY-MM-DD
+	End   string `json:"end"`   // YYYY-MM-DD
+}
+
+type jsonInputs struct {
+	SystemFiles []string `json:"system_files"`
+	BankFiles   []string `json:"bank_files"`
+	FailedFiles []string `json:"failed_files"`
+}
+
+type jsonSummary struct {
+	SystemTransactions     int               `json:"system_transactions"`
+	BankTransactions       int               `json:"bank_transactions"`
+	Matched                int               `json:"matched"`
+	MatchRate              float64           `json:"match_rate"` // Percent
+	UnmatchedSystem        int               `json:"unmatched_system"`
+	UnmatchedBank          int               `json:"unmatched_bank"`
+	Rejected               int               `json:"rejected"`
+	BaseCurrency           domain.Currency   `json:"base_currency"`
+	TotalDiscrepancy       string            `json:"total_discrepancy"`       // Gross: matched discrepancy plus all unmatched amounts
+	MatchedDiscrepancy     string            `json:"matched_discrepancy"`     // Amount differences of matched pairs and groups
+	UnmatchedSystemDebits  string            `json:"unmatched_system_debits"` // Absolute amounts
+	UnmatchedSystemCredits string            `json:"unmatched_system_credits"`
+	UnmatchedBankDebits    string            `json:"unmatched_bank_debits"`
+	UnmatchedBankCredits   string            `json:"unmatched_bank_credits"`
+	NetDifference          string            `json:"net_difference"` // Signed, system minus bank
+	FXDiscrepancy          string            `json:"fx_discrepancy"`
+	UnconvertedDiscrepancy map[string]string `json:"unconverted_discrepancy"` // By currency
+	BankCounts             map[string]int    `json:"bank_counts"`             // Bank transactions by source
+}
+
+type jsonBreakdowns struct {
+	BySource []jsonBreakdown `json:"by_source"`
+	ByType   []jsonBreakdown `json:"by_type"`
+	ByDate   []jsonBreakdown `json:"by_date"` // Keyed YYYY-MM-DD
+}
+
+type jsonBreakdown struct {
+	Key                   string            `json:"key"`
+	SystemTransactions    int               `json:"system_transactions"`
+	BankTransactions      int               `json:"bank_transactions"`
+	MatchedSystem         int               `json:"matched_system"`
+	MatchedBank           int               `json:"matched_bank"`
+	UnmatchedSystem       int               `json:"unmatched_system"`
+	UnmatchedBank         int               `json:"unmatched_bank"`
+	MatchRate             float64           `json:"match_rate"`              // Percent
+	UnmatchedSystemAmount string            `json:"unmatched_system_amount"` // In base currency
+	UnmatchedBankAmount   string            `json:"unmatched_bank_amount"`   // In base currency
+	NetDifference         string            `json:"net_difference"`          // Signed, system minus bank
+	UnconvertedAmount     map[string]string `json:"unconverted_amount"`      // By currency
+}
+
+type jsonTransaction struct {
+	ID       string                 `json:"id"`
+	Side     domain.SourceType      `json:"side"` // SYSTEM or BANK
+	Source   string                 `json:"source"`
+	FileID   string                 `json:"file_id"`
+	Date     time.Time              `json:"date"`
+	Amount   string                 `json:"amount"`
+	Currency domain.Currency        `json:"currency"`
+	Type     domain.TransactionType `json:"type"`
+	RawData  map[string]any         `json:"raw_data"`
+}
+
+type jsonPair struct {
+	System            jsonTransaction `json:"system"`
+	Bank              jsonTransaction `json:"bank"`
+	Confidence        float64         `json:"confidence"` // 0-100
+	AmountDiscrepancy string          `json:"amount_discrepancy"`
+	FXDiscrepancy     string          `json:"fx_discrepancy"`
+	DayOffset         int             `json:"day_offset"` // Bank date minus system date in days
+}
+
+type jsonGroup struct {
+	System            []jsonTransaction `json:"system"`
+	Bank              []jsonTransaction `json:"bank"`
+	Confidence        float64           `json:"confidence"` // 0-100
+	AmountDiscrepancy string            `json:"amount_discrepancy"`
+}
+
+type jsonStatement struct {
+	Source         string           `json:"source"`
+	File           string           `json:"file"`
+	AccountNumber  string           `json:"account_number,omitempty"`
+	Currency       domain.Currency  `json:"currency"`
+	OpeningBalance *string          `json:"opening_balance"` // Null when the statement does not state it
+	ClosingBalance *string          `json:"closing_balance"` // Null when the statement does not state it
+	BalanceStatus  string           `json:"balance_status"`
+	Difference     string           `json:"difference"`
+	Reason         string           `json:"reason,omitempty"`
+	BalanceBreaks  []jsonBalanceRow `json:"balance_breaks"`
+}
+
+type jsonBalanceRow struct {
+	Row        int64  `json:"row"`
+	ID         string `json:"id"`
+	Expected   string `json:"expected"`
+	Stated     string `json:"stated"`
+	Difference string `json:"difference"`
+}
+
+// JSONRenderer writes the full result as one versioned JSON document, for other programs
+type JSONRenderer struct{}
+
+// NewJSONRenderer creates a JSON renderer
+func NewJSONRenderer() *JSONRenderer {
+	return &JSONRenderer{}
+}
+
+// Name returns the name of the format
+func (r *JSONRenderer) Name() string {
+	return "json"
+}
+
+// Render writes the report as an indented JSON document
+func (r *JSONRenderer) Render(w io.Writer, result *matcher.MatchResult, run *Run) error {
+	encoder := json.NewEncoder(w)
+	encoder.SetIndent("", "  ")
+	if err := encoder.Encode(buildJSONReport(result, run)); err != nil {
+		return fmt.Errorf("failed to write JSON report: %w", err)
+	}
+	return nil
+}
+
+// buildJSONReport converts a result into its JSON form. Lists are never null, so
+// consumers can iterate them without checks.
+func buildJSONReport(result *matcher.MatchResult, run *Run) *jsonReport {
+	counts := make(map[string]int, len(result.BySource))
+	for _, b := range result.BySource {
+		if b.BankTxns > 0 {
+			counts[b.Key] = b.BankTxns
+		}
+	}
+
+	report := &jsonReport{
+		SchemaVersion: SchemaVersion,
+		GeneratedAt:   run.GeneratedAt,
+		Algorithm:     result.AlgorithmUsed,
+		Period:        jsonPeriod{Start: run.Start.Format("2006-01-02"), End: run.End.Format("2006-01-02")},
+		Inputs:        jsonInputs{SystemFiles: nonNil(run.SystemFiles), BankFiles: nonNil(run.BankFiles), FailedFiles: nonNil(run.FailedFiles)},
+		Summary: jsonSummary{
+			SystemTransactions:     result.TotalSystemTxns,
+			BankTransactions:       result.TotalBankTxns,
+			Matched:                result.TotalMatched,
+			MatchRate:              result.MatchRate,
+			UnmatchedSystem:        len(result.UnmatchedSystem),
+			UnmatchedBank:          len(result.UnmatchedBank),
+			Rejected:               len(run.Rejects),
+			BaseCurrency:           result.BaseCurrency,
+			TotalDiscrepancy:       result.TotalDiscrepancy.String(),
+			MatchedDiscrepancy:     result.MatchedDiscrepancy.String(),
+			UnmatchedSystemDebits:  result.UnmatchedSystemDebits.String(),
+			UnmatchedSystemCredits: result.UnmatchedSystemCredits.String(),
+			UnmatchedBankDebits:    result.UnmatchedBankDebits.String(),
+			UnmatchedBankCredits:   result.UnmatchedBankCredits.String(),
+			NetDifference:          result.NetDifference.String(),
+			FXDiscrepancy:          result.TotalFXDiscrepancy.String(),
+			UnconvertedDiscrepancy: jsonAmounts(result.UnconvertedDiscrepancy),
+			BankCounts:             counts,
+		},
+		Breakdowns: jsonBreakdowns{
+			BySource: newJSONBreakdowns(result.BySource),
+			ByType:   newJSONBreakdowns(result.ByType),
+			ByDate:   newJSONBreakdowns(result.ByDate),
+		},
+		Matched:         make([]jsonPair, 0, len(result.Matched)),
+		Grouped:         make([]jsonGroup, 0, len(result.Grouped)),
+		UnmatchedSystem: jsonTransactions(result.UnmatchedSystem),
+		UnmatchedBank:   jsonTransactions(result.UnmatchedBank),
+		WrongBank:       make([]jsonPair, 0, len(result.WrongBank)),
+		Statements:      make([]jsonStatement, 0, len(run.Statements)),
+		Rejects:         make([]input.Reject, 0, len(run.Rejects)),
+	}
+
+	for _, pair := range result.Matched {
+		report.Matched = append(report.Matched, newJSONPair(pair))
+	}
+	for _, group := range result.Grouped {
+		report.Grouped = append(report.Grouped, jsonGroup{
+			System:            jsonTransactions(group.SystemTransactions),
+			Bank:              jsonTransactions(group.BankTransactions),
+			Confidence:        group.ConfidenceScore,
+			AmountDiscrepancy: group.AmountDiscrepancy.String(),
+		})
+	}
+	for _, pair := range result.WrongBank {
+		report.WrongBank = append(report.WrongBank, newJSONPair(pair))
+	}
+	for _, stmt := range run.Statements {
+		report.Statements = append(report.Statements, newJSONStatement(stmt))
+	}
+	report.Rejects = append(report.Rejects, run.Rejects...)
+
+	return report
+}
+
+func newJSONPair(pair matcher.MatchPair) jsonPair {
+	return jsonPair{
+		System:            newJSONTransaction(pair.SystemTransaction),
+		Bank:              newJSONTransaction(pair.BankTransaction),
+		Confidence:        pair.ConfidenceScore,
+		AmountDiscrepancy: pair.AmountDiscrepancy.String(),
+		FXDiscrepancy:     pair.FXDiscrepancy.String(),
+		DayOffset:         pair.DayOffset,
+	}
+}
+
+func newJSONBreakdowns(breakdowns []matcher.Breakdown) []jsonBreakdown {
+	converted := make([]jsonBreakdown, 0, len(breakdowns))
+	for _, b := range breakdowns {
+		converted = append(converted, jsonBreakdown{
+			Key:                   b.Key,
+			SystemTransactions:    b.SystemTxns,
+			BankTransactions:      b.BankTxns,
+			MatchedSystem:         b.MatchedSystem,
+			MatchedBank:           b.MatchedBank,
+			UnmatchedSystem:       b.UnmatchedSystem(),
+			UnmatchedBank:         b.UnmatchedBank(),
+			MatchRate:             b.MatchRate,
+			UnmatchedSystemAmount: b.UnmatchedSystemAmount.String(),
+			UnmatchedBankAmount:   b.UnmatchedBankAmount.String(),
+			NetDifference:         b.NetDifference.String(),
+			UnconvertedAmount:     jsonAmounts(b.UnconvertedAmount),
+		})
+	}
+	return converted
+}
+
+// jsonAmounts converts amounts by currency, never returning nil
+func jsonAmounts(amounts map[domain.Currency]domain.Money) map[string]string {
+	converted := make(map[string]string, len(amounts))
+	for currency, amount := range amounts {
+		converted[string(currency)] = amount.String()
+	}
+	return converted
+}
+
+func jsonTransactions(txns []*transaction.Transaction) []jsonTransaction {
+	converted := make([]jsonTransaction, 0, len(txns))
+	for _, txn := range txns {
+		converted = append(converted, newJSONTransaction(txn))
+	}
+	// Same order on every run, whatever order the matcher left them in
+	sort.SliceStable(converted, func(i, j int) bool {
+		if !converted[i].Date.Equal(converted[j].Date) {
+			return converted[i].Date.Before(converted[j].Date)
+		}
+		return converted[i].ID < converted[j].ID
+	})
+	return converted
+}
+
+func newJSONTransaction(txn *transaction.Transaction) jsonTransaction {
+	rawData := txn.RawData
+	if rawData == nil {
+		rawData = make(map[string]any)
+	}
+	return jsonTransaction{
+		ID:       txn.ID,
+		Side:     txn.SourceType,
+		Source:   txn.Source,
+		FileID:   txn.FileID,
+		Date:     txn.TransactionDate,
+		Amount:   txn.Amount.String(),
+		Currency: txn.Amount.Currency,
+		Type:     txn.Type,
+		RawData:  rawData,
+	}
+}
+
+func newJSONStatement(stmt *statement.Statement) jsonStatement {
+	converted := jsonStatement{
+		Source:         stmt.Source,
+		File:           stmt.FilePath,
+		AccountNumber:  stmt.AccountNumber,
+		Currency:       stmt.Currency,
+		OpeningBalance: optionalAmount(stmt.OpeningBalance),
+		ClosingBalance: optionalAmount(stmt.ClosingBalance),
+		BalanceStatus:  string(stmt.Check.Status),
+		Difference:     stmt.Check.Difference.String(),
+		Reason:         stmt.Check.Reason,
+		BalanceBreaks:  make([]jsonBalanceRow, 0, len(stmt.BalanceBreaks)),
+	}
+	for _, brk := range stmt.BalanceBreaks {
+		converted.BalanceBreaks = append(converted.BalanceBreaks, jsonBalanceRow{
+			Row:        brk.RowNumber,
+			ID:         brk.ID,
+			Expected:   brk.Expected.String(),
+			Stated:     brk.Stated.String(),
+			Difference: brk.Difference.String(),
+		})
+	}
+	return converted
+}
+
+func nonNil(files []string) []string {
+	if files == nil {
+		return make([]string, 0)
+	}
+	return files
+}
+
+func optionalAmount(m *domain.Money) *string {
+	if m == nil {
+		return nil
+	}
+	s := m.String()
+	return &s
+}
@@ -0,0 +1,88 @@
+package report
+
+import (
+	"bytes"
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/farhaan/amartha-reconcile-system/internal/domain"
+	"github.com/farhaan/amartha-reconcile-system/internal/domain/transaction"
+	"github.com/farhaan/amartha-reconcile-system/pkg/matcher"
+)
+
+func TestJSONRenderer(t *testing.T) {
+	date := time.Date(2024, 3, 15, 0, 0, 0, 0, time.UTC)
+	newTxn := func(id string, sourceType domain.SourceType, minor int64) *transaction.Transaction {
+		txn := transaction.NewTransaction("job", "file", sourceType, date, domain.NewMoney(minor, "IDR"), domain.TransactionTypeCredit, "BCA")
+		txn.ID = id
+		txn.RawData["trxID"] = id
+		return txn
+	}
+
+	result := matcher.NewMatchResult("exact")
+	result.Matched = append(result.Matched, matcher.MatchPair{
+		SystemTransaction: newTxn("SYS001", domain.SourceTypeSystem, 15050),
+		BankTransaction:   newTxn("BANK001", domain.SourceTypeBank, 15000),
+		ConfidenceScore:   90,
+		AmountDiscrepancy: domain.NewMoney(50, "IDR"),
+	})
+	result.UnmatchedBank = append(result.UnmatchedBank, newTxn("BANK002", domain.SourceTypeBank, -2500))
+	result.Finalize()
+
+	var buf bytes.Buffer
+	run := &Run{Start: date.AddDate(0, 0, -14), End: date.AddDate(0, 0, 16)}
+	if err := NewJSONRenderer().Render(&buf, result, run); err != nil {
+		t.Fatalf("Render failed: %v", err)
+	}
+
+	var report map[string]any
+	if err := json.Unmarshal(buf.Bytes(), &report); err != nil {
+		t.Fatalf("Report is not valid JSON: %v", err)
+	}
+
+	if report["schema_version"] != float64(SchemaVersion) {
+		t.Errorf("Expected schema version %d, got %v", SchemaVersion, report["schema_version"])
+	}
+
+	// Empty lists are written as [], not null
+	for _, key := range []string{"matched", "grouped", "unmatched_system", "unmatched_bank", "wrong_bank", "statements", "rejects"} {
+		if _, ok := report[key].([]any); !ok {
+			t.Errorf("Expected %s to be a list, got %v", key, report[key])
+		}
+	}
+
+	pair := report["matched"].([]any)[0].(map[string]any)
+	if pair["amount_discrepancy"] != "0.50" {
+		t.Errorf("Expected discrepancy 0.50, got %v", pair["amount_discrepancy"])
+	}
+	system := pair["system"].(map[string]any)
+	if system["amount"] != "150.50" || system["side"] != "SYSTEM" {
+		t.Errorf("Expected SYSTEM amount 150.50, got %v %v", system["side"], system["amount"])
+	}
+	if raw := system["raw_data"].(map[string]any); raw["trxID"] != "SYS001" {
+		t.Errorf("Expected raw data to be kept, got %v", raw)
+	}
+
+	bank := report["unmatched_bank"].([]any)[0].(map[string]any)
+	if bank["id"] != "BANK002" || bank["amount"] != "-25.00" {
+		t.Errorf("Expected BANK002 at -25.00, got %v at %v", bank["id"], bank["amount"])
+	}
+
+	summary := report["summary"].(map[string]any)
+	if summary["unmatched_bank"] != float64(1) {
+		t.Errorf("Expected 1 unmatched bank line, got %v", summary["unmatched_bank"])
+	}
+	if counts := summary["bank_counts"].(map[string]any); counts["BCA"] != float64(2) {
+		t.Errorf("Expected 2 BCA bank lines, got %v", counts["BCA"])
+	}
+
+	bySource := report["breakdowns"].(map[string]any)["by_source"].([]any)
+	if len(bySource) != 1 {
+		t.Fatalf("Expected a breakdown for BCA only, got %v", bySource)
+	}
+	bca := bySource[0].(map[string]any)
+	if bca["key"] != "BCA" || bca["unmatched_bank"] != float64(1) || bca["unmatched_bank_amount"] != "25.00" {
+		t.Errorf("Expected BCA with 25.00 unmatched on the bank side, got %v", bca)
+	}
+}
@@ -0,0 +1,218 @@
+package report
+
+import (
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/farhaan/amartha-reconcile-system/internal/domain/transaction"
+	"github.com/farhaan/amartha-reconcile-system/pkg/matcher"
+)
+
+// MarkdownRenderer writes the report as Markdown, for pull requests, wikis and tickets
+type MarkdownRenderer struct{}
+
+// NewMarkdownRenderer creates a Markdown renderer
+func NewMarkdownRenderer() *MarkdownRenderer {
+	return &MarkdownRenderer{}
+}
+
+// Name returns the name of the format
+func (r *MarkdownRenderer) Name() string {
+	return "markdown"
+}
+
+// Render writes the report as Markdown with one table per section
+func (r *MarkdownRenderer) Render(w io.Writer, result *matcher.MatchResult, run *Run) error {
+	md := &markdownWriter{w: w}
+
+	md.line("# Reconciliation Report")
+	md.line("")
+	md.line(fmt.Sprintf("Period %s to %s, matched with `%s`.",
+		run.Start.Format("2006-01-02"), run.End.Format("2006-01-02"), result.AlgorithmUsed))
+	if len(run.SystemFiles) > 0 || len(run.BankFiles) > 0 {
+		md.line("")
+		md.line("System files: " + strings.Join(run.SystemFiles, ", ") + "  ")
+		md.line("Bank files: " + strings.Join(run.BankFiles, ", "))
+	}
+	if len(run.FailedFiles) > 0 {
+		md.line("")
+		md.line("Failed files: " + strings.Join(run.FailedFiles, ", "))
+	}
+
+	md.heading("Summary")
+	md.table([]string{"Metric", "Value"}, [][]string{
+		{"System transactions", fmt.Sprint(result.TotalSystemTxns)},
+		{"Bank transactions", fmt.Sprint(result.TotalBankTxns)},
+		{"Matched", fmt.Sprintf("%d (%.1f%%)", result.TotalMatched, result.MatchRate)},
+		{"Unmatched system", fmt.Sprint(len(result.UnmatchedSystem))},
+		{"Unmatched bank", fmt.Sprint(len(result.UnmatchedBank))},
+		{"Total discrepancy", fmt.Sprintf("%s %s", result.TotalDiscrepancy, result.BaseCurrency)},
+		{"Matched pair discrepancies", fmt.Sprintf("%s %s", result.MatchedDiscrepancy, result.BaseCurrency)},
+		{"Unmatched system debits", fmt.Sprintf("%s %s", result.UnmatchedSystemDebits, result.BaseCurrency)},
+		{"Unmatched system credits", fmt.Sprintf("%s %s", result.UnmatchedSystemCredits, result.BaseCurrency)},
+		{"Unmatched bank debits", fmt.Sprintf("%s %s", result.UnmatchedBankDebits, result.BaseCurrency)},
+		{"Unmatched bank credits", fmt.Sprintf("%s %s", result.UnmatchedBankCredits, result.BaseCurrency)},
+		{"Net difference (system - bank)", fmt.Sprintf("%s %s", result.NetDifference, result.BaseCurrency)},
+		{"FX discrepancy", fmt.Sprintf("%s %s", result.TotalFXDiscrepancy, result.BaseCurrency)},
+		{"Rejected rows", fmt.Sprint(len(run.Rejects))},
+	})
+	for _, currency := range sortedCurrencies(result.UnconvertedDiscrepancy) {
+		md.line(fmt.Sprintf("\nUnconverted discrepancy: %s %s", result.UnconvertedDiscrepancy[currency], currency))
+	}
+
+	if len(result.BySource) > 0 {
+		md.heading("By Bank")
+		md.breakdown("Bank", result.BySource)
+		md.heading("By Type")
+		md.breakdown("Type", result.ByType)
+		md.heading("By Day")
+		md.breakdown("Date", result.ByDate)
+	}
+
+	if len(run.Statements) > 0 {
+		md.heading("Statement Balance Checks")
+		rows := make([][]string, 0, len(run.Statements))
+		for _, stmt := range run.Statements {
+			opening, closing := "", ""
+			if stmt.OpeningBalance != nil {
+				opening = stmt.OpeningBalance.String()
+			}
+			if stmt.ClosingBalance != nil {
+				closing = stmt.ClosingBalance.String()
+			}
+			rows = append(rows, []string{stmt.Source, string(stmt.Check.Status), opening, closing, stmt.Check.Difference.String(), stmt.Check.Reason})
+		}
+		md.table([]string{"Bank", "Status", "Opening", "Closing", "Difference", "Reason"}, rows)
+	}
+
+	if len(run.Rejects) > 0 {
+		md.heading("Rejected Rows")
+		files, counts := run.RejectCounts()
+		rows := make([][]string, 0)
+		for _, file := range files {
+			for _, category := range sortedKeys(counts[file]) {
+				rows = append(rows, []string{file, category, fmt.Sprint(counts[file][category])})
+			}
+		}
+		md.table([]string{"File", "Reason", "Rows"}, rows)
+	}
+
+	if discrepancies := discrepantRows(pairRows(result)); len(discrepancies) > 0 {
+		md.heading("Matches with Discrepancies")
+		rows := make([][]string, 0, len(discrepancies))
+		for _, row := range discrepancies {
+			rows = append(rows, []string{
+				row.MatchID, row.System.ID, row.System.AbsAmount().String(), row.Bank.ID, row.Bank.AbsAmount().String(),
+				row.AmountDiscrepancy.String(), row.FXDiscrepancy.String(), fmt.Sprintf("%.1f%%", row.Confidence),
+			})
+		}
+		md.table([]string{"Match", "System", "Amount", "Bank", "Amount", "Discrepancy", "FX difference", "Confidence"}, rows)
+	}
+
+	if len(result.Grouped) > 0 {
+		md.heading("Grouped Matches")
+		rows := make([][]string, 0, len(result.Grouped))
+		for _, group := range result.Grouped {
+			rows = append(rows, []string{
+				joinTransactionIDs(group.SystemTransactions),
+				joinTransactionIDs(group.BankTransactions),
+				sumAbsAmounts(group.BankTransactions).String(),
+				group.BankTransactions[0].TransactionDate.Format("2006-01-02"),
+			})
+		}
+		md.table([]string{"System", "Bank", "Amount", "Date"}, rows)
+	}
+
+	if len(result.WrongBank) > 0 {
+		md.heading("Wrong Bank Exceptions")
+		rows := make([][]string, 0, len(result.WrongBank))
+		for _, pair := range result.WrongBank {
+			rows = append(rows, []string{
+				pair.SystemTransaction.ID, pair.SystemTransaction.Source,
+				pair.BankTransaction.ID, pair.BankTransaction.Source,
+				pair.SystemTransaction.AbsAmount().String(),
+				pair.SystemTransaction.TransactionDate.Format("2006-01-02"),
+			})
+		}
+		md.table([]string{"System", "System bank", "Bank", "Statement bank", "Amount", "Date"}, rows)
+	}
+
+	if len(result.UnmatchedSystem) > 0 {
+		md.heading("Unmatched System Transactions")
+		md.transactions(result.UnmatchedSystem)
+	}
+
+	if len(result.UnmatchedBank) > 0 {
+		md.heading("Unmatched Bank Transactions")
+		md.transactions(result.UnmatchedBank)
+	}
+
+	return md.err
+}
+
+// markdownWriter writes Markdown, keeping the first error
+type markdownWriter struct {
+	w   io.Writer
+	err error
+}
+
+func (m *markdownWriter) line(s string) {
+	if m.err == nil {
+		_, m.err = fmt.Fprintln(m.w, s)
+	}
+}
+
+func (m *markdownWriter) heading(title string) {
+	m.line("")
+	m.line("## " + title)
+	m.line("")
+}
+
+func (m *markdownWriter) table(header []string, rows [][]string) {
+	separators := make([]string, len(header))
+	for i := range separators {
+		separators[i] = "---"
+	}
+	m.row(header)
+	m.row(separators)
+	for _, row := range rows {
+		m.row(row)
+	}
+}
+
+func (m *markdownWriter) row(cells []string) {
+	escaped := make([]string, len(cells))
+	for i, cell := range cells {
+		escaped[i] = markdownEscaper.Replace(cell)
+	}
+	m.line("| " + strings.Join(escaped, " | ") + " |")
+}
+
+func (m *markdownWriter) breakdown(key string, breakdowns []matcher.Breakdown) {
+	rows := make([][]string, 0, len(breakdowns))
+	for _, b := range breakdowns {
+		rows = append(rows, []string{
+			b.Key, fmt.Sprint(b.SystemTxns), fmt.Sprint(b.BankTxns), fmt.Sprint(b.MatchedSystem + b.MatchedBank),
+			fmt.Sprint(b.UnmatchedSystem()), fmt.Sprint(b.UnmatchedBank()),
+			b.UnmatchedSystemAmount.String(), b.UnmatchedBankAmount.String(), b.NetDifference.String(), formatAmounts(b.UnconvertedAmount),
+			fmt.Sprintf("%.1f%%", b.MatchRate),
+		})
+	}
+	m.table([]string{key, "System", "Bank lines", "Matched", "Unmatched system", "Unmatched bank",
+		"Unmatched system amount", "Unmatched bank amount", "Net difference", "Unconverted", "Match rate"}, rows)
+}
+
+func (m *markdownWriter) transactions(txns []*transaction.Transaction) {
+	rows := make([][]string, 0, len(txns))
+	for _, txn := range txns {
+		rows = append(rows, []string{
+			txn.ID, txn.Source, string(txn.Type), txn.AbsAmount().String(), string(txn.Amount.Currency),
+			txn.TransactionDate.Format("2006-01-02"), references(txn),
+		})
+	}
+	m.table([]string{"ID", "Bank", "Type", "Amount", "Currency", "Date", "Reference"}, rows)
+}
+
+// markdownEscaper keeps cell text from breaking out of its table cell
+var markdownEscaper = strings.NewReplacer("|", `\|`, "\n", " ", "\r", "")
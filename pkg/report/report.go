@@ -0,0 +1,214 @@
+// Package report renders reconciliation results for people and for other programs
+package report
+
+import (
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/farhaan/amartha-reconcile-system/internal/domain"
+	"github.com/farhaan/amartha-reconcile-system/internal/domain/statement"
+	"github.com/farhaan/amartha-reconcile-system/internal/domain/transaction"
+	"github.com/farhaan/amartha-reconcile-system/internal/infrastructure/input"
+	"github.com/farhaan/amartha-reconcile-system/pkg/matcher"
+)
+
+// Run describes the reconciliation run a result came from
+type Run struct {
+	Start, End  time.Time // Reconciliation period
+	GeneratedAt time.Time // When the report was made, set by the caller so output can be reproduced
+	SystemFiles []string  // Files that were read
+	BankFiles   []string
+	FailedFiles []string // Files that were not found or could not be read
+	Statements  []*statement.Statement
+	Rejects     []input.Reject // Rows left out of the input files
+}
+
+// Renderer writes a reconciliation report in one format
+type Renderer interface {
+	// Name identifies the format, e.g. "text" or "json"
+	Name() string
+	// Render writes the report of a result
+	Render(w io.Writer, result *matcher.MatchResult, run *Run) error
+}
+
+// Formats lists the names NewRenderer accepts
+func Formats() []string {
+	return []string{"text", "json", "csv", "markdown", "html"}
+}
+
+// NewRenderer creates the renderer registered under the given name
+func NewRenderer(name string) (Renderer, error) {
+	switch name {
+	case "text":
+		return NewTextRenderer(), nil
+	case "json":
+		return NewJSONRenderer(), nil
+	case "csv":
+		return NewCSVRenderer(), nil
+	case "markdown":
+		return NewMarkdownRenderer(), nil
+	case "html":
+		return NewHTMLRenderer(), nil
+	default:
+		return nil, fmt.Errorf("unknown report format %q, expected one of %s", name, strings.Join(Formats(), ", "))
+	}
+}
+
+// RejectCounts counts rejected rows by file and category, with files in the order they were read
+func (r *Run) RejectCounts() ([]string, map[string]map[string]int) {
+	files := make([]string, 0)
+	counts := make(map[string]map[string]int)
+	for _, reject := range r.Rejects {
+		if counts[reject.File] == nil {
+			files = append(files, reject.File)
+			counts[reject.File] = make(map[string]int)
+		}
+		counts[reject.File][string(reject.Category)]++
+	}
+	return files, counts
+}
+
+// pairRow is one matched system and bank transaction, from a pair or a group
+type pairRow struct {
+	MatchID           string // M1, M2... for pairs, G1, G2... for groups
+	System, Bank      *transaction.Transaction
+	Confidence        float64
+	AmountDiscrepancy domain.Money
+	FXDiscrepancy     domain.Money
+	DayOffset         int
+}
+
+// pairRows flattens matched pairs and groups into rows. A group becomes one row per
+// transaction on its larger side, each next to the single transaction on the other.
+// Only the first row of a group carries its discrepancy, so the column adds up.
+func pairRows(result *matcher.MatchResult) []pairRow {
+	rows := make([]pairRow, 0, len(result.Matched))
+	for i, pair := range result.Matched {
+		rows = append(rows, pairRow{
+			MatchID:           fmt.Sprintf("M%d", i+1),
+			System:            pair.SystemTransaction,
+			Bank:              pair.BankTransaction,
+			Confidence:        pair.ConfidenceScore,
+			AmountDiscrepancy: pair.AmountDiscrepancy,
+			FXDiscrepancy:     pair.FXDiscrepancy,
+			DayOffset:         pair.DayOffset,
+		})
+	}
+
+	for i, group := range result.Grouped {
+		n := max(len(group.SystemTransactions), len(group.BankTransactions))
+		for j := 0; j < n; j++ {
+			row := pairRow{
+				MatchID:    fmt.Sprintf("G%d", i+1),
+				System:     groupMember(group.SystemTransactions, j),
+				Bank:       groupMember(group.BankTransactions, j),
+				Confidence: group.ConfidenceScore,
+			}
+			if j == 0 {
+				row.AmountDiscrepancy = group.AmountDiscrepancy
+			}
+			rows = append(rows, row)
+		}
+	}
+	return rows
+}
+
+// discrepantRows returns the rows of the matches whose amounts differ, before or after
+// FX conversion, with every row of such a group
+func discrepantRows(rows []pairRow) []pairRow {
+	differs := make(map[string]bool)
+	for _, row := range rows {
+		if !row.AmountDiscrepancy.IsZero() || !row.FXDiscrepancy.IsZero() {
+			differs[row.MatchID] = true
+		}
+	}
+
+	discrepant := make([]pairRow, 0)
+	for _, row := range rows {
+		if differs[row.MatchID] {
+			discrepant = append(discrepant, row)
+		}
+	}
+	return discrepant
+}
+
+// groupMember returns the j-th transaction of a group side, or its only one
+func groupMember(txns []*transaction.Transaction, j int) *transaction.Transaction {
+	if len(txns) == 1 {
+		return txns[0]
+	}
+	return txns[j]
+}
+
+// references returns the customer and bank references of an MT940 statement line
+// as written in the statement (NONREF//bank reference), or "" for transactions without them
+func references(txn *transaction.Transaction) string {
+	customerRef, _ := txn.RawData["customerReference"].(string)
+	bankRef, _ := txn.RawData["bankReference"].(string)
+	if customerRef == "" && bankRef == "" {
+		return ""
+	}
+	if customerRef == "" {
+		customerRef = "NONREF"
+	}
+	if bankRef == "" {
+		return customerRef
+	}
+	return customerRef + "//" + bankRef
+}
+
+// bySource groups transactions by source bank, with the banks sorted
+func bySource(txns []*transaction.Transaction) ([]string, map[string][]*transaction.Transaction) {
+	groups := make(map[string][]*transaction.Transaction)
+	for _, txn := range txns {
+		groups[txn.Source] = append(groups[txn.Source], txn)
+	}
+	return sortedKeys(groups), groups
+}
+
+func joinTransactionIDs(txns []*transaction.Transaction) string {
+	ids := make([]string, len(txns))
+	for i, txn := range txns {
+		ids[i] = txn.ID
+	}
+	return strings.Join(ids, " + ")
+}
+
+func sumAbsAmounts(txns []*transaction.Transaction) domain.Money {
+	var total domain.Money
+	for _, txn := range txns {
+		total = total.Add(txn.AbsAmount())
+	}
+	return total
+}
+
+// formatAmounts lists amounts by currency in currency order, e.g. "5.00 SGD, 10.00 USD"
+func formatAmounts(amounts map[domain.Currency]domain.Money) string {
+	parts := make([]string, 0, len(amounts))
+	for _, currency := range sortedCurrencies(amounts) {
+		parts = append(parts, fmt.Sprintf("%s %s", amounts[currency], currency))
+	}
+	return strings.Join(parts, ", ")
+}
+
+// sortedCurrencies returns the currencies of unconverted discrepancies in order
+func sortedCurrencies(amounts map[domain.Currency]domain.Money) []domain.Currency {
+	currencies := make([]domain.Currency, 0, len(amounts))
+	for currency := range amounts {
+		currencies = append(currencies, currency)
+	}
+	sort.Slice(currencies, func(i, j int) bool { return currencies[i] < currencies[j] })
+	return currencies
+}
+
+func sortedKeys[V any](m map[string]V) []string {
+	keys := make([]string, 0, len(m))
+	for key := range m {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
+}
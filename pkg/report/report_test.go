@@ -0,0 +1,147 @@
+package report
+
+import (
+	"bytes"
+	"flag"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/farhaan/amartha-reconcile-system/internal/domain"
+	"github.com/farhaan/amartha-reconcile-system/internal/domain/statement"
+	"github.com/farhaan/amartha-reconcile-system/internal/domain/transaction"
+	"github.com/farhaan/amartha-reconcile-system/internal/infrastructure/csv"
+	"github.com/farhaan/amartha-reconcile-system/internal/infrastructure/input"
+	"github.com/farhaan/amartha-reconcile-system/pkg/matcher"
+)
+
+var update = flag.Bool("update", false, "rewrite the golden files in testdata")
+
+func TestRenderers_Golden(t *testing.T) {
+	result, run := goldenRun()
+
+	for _, name := range Formats() {
+		renderer, err := NewRenderer(name)
+		if err != nil {
+			t.Fatalf("NewRenderer(%s) failed: %v", name, err)
+		}
+		if renderer.Name() != name {
+			t.Errorf("Expected renderer %s, got %s", name, renderer.Name())
+		}
+
+		var buf bytes.Buffer
+		if err := renderer.Render(&buf, result, run); err != nil {
+			t.Fatalf("%s: Render failed: %v", name, err)
+		}
+
+		golden := filepath.Join("testdata", "report."+name+".golden")
+		if *update {
+			if err := os.WriteFile(golden, buf.Bytes(), 0o644); err != nil {
+				t.Fatalf("failed to update %s: %v", golden, err)
+			}
+			continue
+		}
+
+		want, err := os.ReadFile(golden)
+		if err != nil {
+			t.Fatalf("failed to read %s (run go test -update to create it): %v", golden, err)
+		}
+		if !bytes.Equal(buf.Bytes(), want) {
+			t.Errorf("%s report differs from %s, run go test -update and review the diff\n%s", name, golden, buf.String())
+		}
+	}
+}
+
+func TestNewRenderer_Unknown(t *testing.T) {
+	if _, err := NewRenderer("pdf"); err == nil {
+		t.Error("Expected error for an unknown format")
+	}
+}
+
+// goldenRun returns a result with every kind of section a report can have
+func goldenRun() (*matcher.MatchResult, *Run) {
+	day1 := time.Date(2024, 3, 15, 0, 0, 0, 0, time.UTC)
+	day2 := day1.AddDate(0, 0, 1)
+	newTxn := func(id, source string, sourceType domain.SourceType, date time.Time, amount string, currency domain.Currency) *transaction.Transaction {
+		money, err := domain.ParseMoney(amount, currency)
+		if err != nil {
+			panic(err)
+		}
+		txnType := domain.TransactionTypeCredit
+		if money.IsNegative() {
+			txnType = domain.TransactionTypeDebit
+		}
+		txn := transaction.NewTransaction("job", string(sourceType)+"-file", sourceType, date, money, txnType, source)
+		txn.ID = id
+		return txn
+	}
+
+	result := matcher.NewMatchResult("split")
+	result.Matched = append(result.Matched,
+		matcher.MatchPair{
+			SystemTransaction: newTxn("TRX001", "BCA", domain.SourceTypeSystem, day1, "-150.50", "IDR"),
+			BankTransaction:   newTxn("BCA_001", "BCA", domain.SourceTypeBank, day1, "-150.50", "IDR"),
+			ConfidenceScore:   100,
+		},
+		matcher.MatchPair{
+			SystemTransaction: newTxn("TRX002", "MANDIRI", domain.SourceTypeSystem, day1, "2500.00", "IDR"),
+			BankTransaction:   newTxn("MANDIRI_002", "MANDIRI", domain.SourceTypeBank, day2, "2497.50", "IDR"),
+			ConfidenceScore:   95,
+			AmountDiscrepancy: domain.NewMoney(250, "IDR"),
+			DayOffset:         1,
+		},
+		matcher.MatchPair{
+			SystemTransaction: newTxn("TRX003", "BCA", domain.SourceTypeSystem, day2, "10.00", "USD"),
+			BankTransaction:   newTxn("BCA_003", "BCA", domain.SourceTypeBank, day2, "10.05", "USD"),
+			ConfidenceScore:   90,
+			FXDiscrepancy:     domain.NewMoney(5, "USD"),
+		},
+	)
+	result.Grouped = append(result.Grouped, matcher.MatchGroup{
+		SystemTransactions: []*transaction.Transaction{
+			newTxn("TRX004", "BNI", domain.SourceTypeSystem, day2, "-100.00", "IDR"),
+			newTxn("TRX005", "BNI", domain.SourceTypeSystem, day2, "-200.00", "IDR"),
+		},
+		BankTransactions: []*transaction.Transaction{newTxn("BNI_004", "BNI", domain.SourceTypeBank, day2, "-300.00", "IDR")},
+		ConfidenceScore:  98,
+	})
+
+	wrongSystem := newTxn("TRX006", "BCA", domain.SourceTypeSystem, day1, "75.00", "IDR")
+	wrongBank := newTxn("MANDIRI_006", "MANDIRI", domain.SourceTypeBank, day1, "75.00", "IDR")
+	mt940Line := newTxn("MANDIRI_007", "MANDIRI", domain.SourceTypeBank, day2, "-42.00", "IDR")
+	mt940Line.RawData["customerReference"] = "INV|42"
+	mt940Line.RawData["bankReference"] = "8891"
+	result.UnmatchedSystem = append(result.UnmatchedSystem, wrongSystem, newTxn("TRX007", "BNI", domain.SourceTypeSystem, day2, "-5.00", "IDR"))
+	result.UnmatchedBank = append(result.UnmatchedBank, mt940Line, wrongBank)
+	result.WrongBank = append(result.WrongBank, matcher.MatchPair{SystemTransaction: wrongSystem, BankTransaction: wrongBank})
+	result.Finalize()
+
+	opening, closing := domain.NewMoney(2000000, "IDR"), domain.NewMoney(1984950, "IDR")
+	verified := statement.NewStatement("BCA", "bca_statement_2024-03-15.csv", "IDR")
+	verified.AccountNumber = "1234567890"
+	verified.OpeningBalance, verified.ClosingBalance = &opening, &closing
+	verified.Check = statement.BalanceCheck{Status: statement.BalanceVerified, Difference: domain.NewMoney(0, "IDR")}
+
+	mismatch := statement.NewStatement("MANDIRI", "mandiri_statement_2024-03-15.csv", "IDR")
+	mismatch.Check = statement.BalanceCheck{Status: statement.BalanceMismatch, Difference: domain.NewMoney(-4200, "IDR"), Reason: "closing balance is 42.00 lower than opening balance plus transactions"}
+	mismatch.BalanceBreaks = []statement.BalanceBreak{{
+		RowNumber: 3, ID: "MANDIRI_007",
+		Expected: domain.NewMoney(10000, "IDR"), Stated: domain.NewMoney(5800, "IDR"), Difference: domain.NewMoney(-4200, "IDR"),
+	}}
+
+	run := &Run{
+		Start:       time.Date(2024, 3, 1, 0, 0, 0, 0, time.UTC),
+		End:         time.Date(2024, 3, 31, 0, 0, 0, 0, time.UTC),
+		GeneratedAt: time.Date(2024, 4, 1, 8, 30, 0, 0, time.UTC),
+		SystemFiles: []string{"system_transactions.csv"},
+		BankFiles:   []string{"bca_statement_2024-03-15.csv", "mandiri_statement_2024-03-15.csv"},
+		FailedFiles: []string{"bni_statement_2024-03-15.csv"},
+		Statements:  []*statement.Statement{verified, mismatch},
+		Rejects: []input.Reject{
+			{File: "system_transactions.csv", Row: 9, Category: csv.CategoryBadAmount, Reason: `invalid amount "12,50"`, Record: []string{"TRX009", "12,50", "BCA", "CREDIT", "2024-03-15T10:00:00Z"}},
+			{File: "system_transactions.csv", Row: 11, Category: csv.CategoryBadDate, Reason: `invalid date "yesterday"`, Record: []string{"TRX011", "10.00", "BCA", "CREDIT", "yesterday"}},
+		},
+	}
+	return result, run
+}
@@ -0,0 +1,319 @@
+package report
+
+import (
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/farhaan/amartha-reconcile-system/internal/domain"
+	"github.com/farhaan/amartha-reconcile-system/internal/domain/statement"
+	"github.com/farhaan/amartha-reconcile-system/internal/domain/transaction"
+	"github.com/farhaan/amartha-reconcile-system/pkg/matcher"
+)
+
+// TextRenderer writes the plain text report shown on the terminal
+type TextRenderer struct{}
+
+// NewTextRenderer creates a text renderer
+func NewTextRenderer() *TextRenderer {
+	return &TextRenderer{}
+}
+
+// Name returns the name of the format
+func (r *TextRenderer) Name() string {
+	return "text"
+}
+
+// Render writes the report as plain text
+func (r *TextRenderer) Render(w io.Writer, result *matcher.MatchResult, run *Run) error {
+	fmt.Fprintln(w, "RECONCILIATION REPORT")
+
+	// Period
+	fmt.Fprintf(w, "Reconciliation Period: %s to %s\n", run.Start.Format("2006-01-02"), run.End.Format("2006-01-02"))
+	if len(run.SystemFiles) > 0 {
+		fmt.Fprintf(w, "System files: %s\n", strings.Join(run.SystemFiles, ", "))
+	}
+	if len(run.BankFiles) > 0 {
+		fmt.Fprintf(w, "Bank files:   %s\n", strings.Join(run.BankFiles, ", "))
+	}
+	if len(run.FailedFiles) > 0 {
+		fmt.Fprintf(w, "Failed files: %s\n", strings.Join(run.FailedFiles, ", "))
+	}
+	fmt.Fprintln(w)
+	// Summary
+	fmt.Fprintln(w, "SUMMARY")
+	fmt.Fprintln(w, "---------------------------------------------------------")
+	fmt.Fprintf(w, "Total Transactions Processed:   %d\n", result.TotalSystemTxns+result.TotalBankTxns)
+	fmt.Fprintf(w, "System transactions:            %d\n", result.TotalSystemTxns)
+	fmt.Fprintf(w, "Bank transactions:              %d\n", result.TotalBankTxns)
+	fmt.Fprintf(w, "Matched Transactions:           %d (%.1f%%)\n", result.TotalMatched, result.MatchRate)
+	fmt.Fprintf(w, "Unmatched Transactions:         %d\n", len(result.UnmatchedSystem)+len(result.UnmatchedBank))
+	fmt.Fprintf(w, "Unmatched system:               %d\n", len(result.UnmatchedSystem))
+	fmt.Fprintf(w, "Unmatched bank:                 %d\n", len(result.UnmatchedBank))
+	fmt.Fprintf(w, "Total Discrepancy Amount:       %s %s\n", result.TotalDiscrepancy, result.BaseCurrency)
+	fmt.Fprintf(w, "  Matched pair discrepancies:   %s %s\n", result.MatchedDiscrepancy, result.BaseCurrency)
+	fmt.Fprintf(w, "  Unmatched system debits:      %s %s\n", result.UnmatchedSystemDebits, result.BaseCurrency)
+	fmt.Fprintf(w, "  Unmatched system credits:     %s %s\n", result.UnmatchedSystemCredits, result.BaseCurrency)
+	fmt.Fprintf(w, "  Unmatched bank debits:        %s %s\n", result.UnmatchedBankDebits, result.BaseCurrency)
+	fmt.Fprintf(w, "  Unmatched bank credits:       %s %s\n", result.UnmatchedBankCredits, result.BaseCurrency)
+	fmt.Fprintf(w, "Net Difference (System - Bank): %s %s\n", result.NetDifference, result.BaseCurrency)
+	if !result.TotalFXDiscrepancy.IsZero() {
+		fmt.Fprintf(w, "FX Discrepancy Amount:          %s %s\n", result.TotalFXDiscrepancy, result.BaseCurrency)
+	}
+	for _, currency := range sortedCurrencies(result.UnconvertedDiscrepancy) {
+		fmt.Fprintf(w, "Unconverted Discrepancy:        %s %s\n", result.UnconvertedDiscrepancy[currency], currency)
+	}
+	fmt.Fprintln(w)
+
+	// Which bank, type or day the differences come from
+	writeTextBreakdown(w, "BY BANK", result.BySource)
+	writeTextBreakdown(w, "BY TYPE", result.ByType)
+	writeTextBreakdown(w, "BY DAY", result.ByDate)
+
+	// Statements that do not add up from opening to closing balance
+	if len(run.Statements) > 0 {
+		fmt.Fprintln(w, "STATEMENT BALANCE CHECKS")
+		fmt.Fprintln(w, "---------------------------------------------------------")
+		for _, stmt := range run.Statements {
+			switch stmt.Check.Status {
+			case statement.BalanceVerified:
+				fmt.Fprintf(w, "%-10s | %-10s | Opening: %s | Closing: %s\n",
+					stmt.Source, stmt.Check.Status, stmt.OpeningBalance, stmt.ClosingBalance)
+			case statement.BalanceMismatch:
+				fmt.Fprintf(w, "%-10s | %-10s | Difference: %s | %s\n",
+					stmt.Source, stmt.Check.Status, stmt.Check.Difference, stmt.Check.Reason)
+			default:
+				fmt.Fprintf(w, "%-10s | %-10s | %s\n", stmt.Source, stmt.Check.Status, stmt.Check.Reason)
+			}
+		}
+		fmt.Fprintln(w)
+	}
+
+	// Rows left out of the input files, by file and reason
+	if len(run.Rejects) > 0 {
+		fmt.Fprintln(w, "REJECTED ROWS")
+		fmt.Fprintln(w, "---------------------------------------------------------")
+		fmt.Fprintf(w, "%d rows could not be read and were left out of matching:\n", len(run.Rejects))
+		fmt.Fprintln(w)
+
+		files, counts := run.RejectCounts()
+		for _, file := range files {
+			categories := make([]string, 0, len(counts[file]))
+			for _, category := range sortedKeys(counts[file]) {
+				categories = append(categories, fmt.Sprintf("%s: %d", category, counts[file][category]))
+			}
+			fmt.Fprintf(w, "%s | %s\n", file, strings.Join(categories, " | "))
+		}
+		fmt.Fprintln(w)
+	}
+
+	// Rows where the running balance chain breaks
+	hasBalanceBreaks := false
+	for _, stmt := range run.Statements {
+		if len(stmt.BalanceBreaks) > 0 {
+			hasBalanceBreaks = true
+			break
+		}
+	}
+
+	if hasBalanceBreaks {
+		fmt.Fprintln(w, "RUNNING BALANCE BREAKS")
+		fmt.Fprintln(w, "---------------------------------------------------------")
+		fmt.Fprintln(w, "Rows whose balance does not follow from the previous row:")
+		fmt.Fprintln(w)
+
+		for _, stmt := range run.Statements {
+			for _, brk := range stmt.BalanceBreaks {
+				fmt.Fprintf(w, "%-10s | Row: %-4d | ID: %-15s | Expected: %10s | Stated: %10s | Difference: %s\n",
+					stmt.Source, brk.RowNumber, brk.ID, brk.Expected, brk.Stated, brk.Difference)
+			}
+		}
+		fmt.Fprintln(w)
+	}
+
+	// Matched transactions with discrepancies
+	if len(result.Matched) > 0 {
+		hasDiscrepancies := false
+		for _, match := range result.Matched {
+			if !match.AmountDiscrepancy.IsZero() {
+				hasDiscrepancies = true
+				break
+			}
+		}
+
+		if hasDiscrepancies {
+			fmt.Fprintln(w, "MATCHED TRANSACTIONS WITH DISCREPANCIES")
+			fmt.Fprintln(w, "---------------------------------------------------------")
+			for _, match := range result.Matched {
+				if !match.AmountDiscrepancy.IsZero() {
+					fmt.Fprintf(w, "System: %s (%s) ↔ Bank: %s (%s) | Discrepancy: %s | Confidence: %.1f%%\n",
+						match.SystemTransaction.ID,
+						match.SystemTransaction.AbsAmount(),
+						match.BankTransaction.ID,
+						match.BankTransaction.AbsAmount(),
+						match.AmountDiscrepancy,
+						match.ConfidenceScore)
+				}
+			}
+			fmt.Fprintln(w)
+		}
+
+		hasFXDifferences := false
+		for _, match := range result.Matched {
+			if !match.FXDiscrepancy.IsZero() {
+				hasFXDifferences = true
+				break
+			}
+		}
+
+		if hasFXDifferences {
+			fmt.Fprintln(w, "MATCHED TRANSACTIONS WITH FX DIFFERENCES")
+			fmt.Fprintln(w, "---------------------------------------------------------")
+			for _, match := range result.Matched {
+				if !match.FXDiscrepancy.IsZero() {
+					fmt.Fprintf(w, "System: %s (%s %s) ↔ Bank: %s (%s %s) | FX Difference: %s %s\n",
+						match.SystemTransaction.ID,
+						match.SystemTransaction.AbsAmount(),
+						match.SystemTransaction.Amount.Currency,
+						match.BankTransaction.ID,
+						match.BankTransaction.AbsAmount(),
+						match.BankTransaction.Amount.Currency,
+						match.FXDiscrepancy,
+						match.FXDiscrepancy.Currency)
+				}
+			}
+			fmt.Fprintln(w)
+		}
+
+		hasDateOffsets := false
+		for _, match := range result.Matched {
+			if match.DayOffset != 0 {
+				hasDateOffsets = true
+				break
+			}
+		}
+
+		if hasDateOffsets {
+			fmt.Fprintln(w, "MATCHED TRANSACTIONS WITH DATE OFFSETS")
+			fmt.Fprintln(w, "---------------------------------------------------------")
+			for _, match := range result.Matched {
+				if match.DayOffset != 0 {
+					fmt.Fprintf(w, "System: %s (%s) ↔ Bank: %s (%s) | Offset: %+d days\n",
+						match.SystemTransaction.ID,
+						match.SystemTransaction.TransactionDate.Format("2006-01-02"),
+						match.BankTransaction.ID,
+						match.BankTransaction.TransactionDate.Format("2006-01-02"),
+						match.DayOffset)
+				}
+			}
+			fmt.Fprintln(w)
+		}
+	}
+
+	fmt.Fprintln(w)
+
+	// Many-to-one and one-to-many matches
+	if len(result.Grouped) > 0 {
+		fmt.Fprintln(w, "GROUPED MATCHES")
+		fmt.Fprintln(w, "---------------------------------------------------------")
+		for _, group := range result.Grouped {
+			fmt.Fprintf(w, "System: %s ↔ Bank: %s | Amount: %10s | Date: %s\n",
+				joinTransactionIDs(group.SystemTransactions),
+				joinTransactionIDs(group.BankTransactions),
+				sumAbsAmounts(group.BankTransactions),
+				group.BankTransactions[0].TransactionDate.Format("2006-01-02"))
+		}
+		fmt.Fprintln(w)
+	}
+
+	// Cross-bank near-misses
+	if len(result.WrongBank) > 0 {
+		fmt.Fprintln(w, "WRONG BANK EXCEPTIONS")
+		fmt.Fprintln(w, "---------------------------------------------------------")
+		fmt.Fprintln(w, "Same date, type and amount but recorded against a different bank:")
+		fmt.Fprintln(w)
+
+		for _, pair := range result.WrongBank {
+			fmt.Fprintf(w, "System: %s (%s) ↔ Bank: %s (%s) | Amount: %10s | Date: %s\n",
+				pair.SystemTransaction.ID,
+				pair.SystemTransaction.Source,
+				pair.BankTransaction.ID,
+				pair.BankTransaction.Source,
+				pair.SystemTransaction.AbsAmount(),
+				pair.SystemTransaction.TransactionDate.Format("2006-01-02"))
+		}
+		fmt.Fprintln(w)
+	}
+
+	// Unmatched system transactions
+	if len(result.UnmatchedSystem) > 0 {
+		fmt.Fprintln(w, "UNMATCHED SYSTEM TRANSACTIONS")
+		fmt.Fprintln(w, "---------------------------------------------------------")
+		fmt.Fprintln(w, "Transactions in system but missing in bank statement(s):")
+		fmt.Fprintln(w)
+
+		for _, txn := range result.UnmatchedSystem {
+			typeStr := "CREDIT"
+			if txn.Type == domain.TransactionTypeDebit {
+				typeStr = "DEBIT"
+			}
+			fmt.Fprintf(w, "ID: %-15s | Source: %-10s | Type: %-6s | Amount: %10s %s | Date: %s\n",
+				txn.ID, txn.Source, typeStr, txn.AbsAmount(), txn.Amount.Currency, txn.TransactionDate.Format("2006-01-02"))
+		}
+		fmt.Fprintln(w)
+	}
+
+	// Unmatched bank transactions (grouped by bank)
+	if len(result.UnmatchedBank) > 0 {
+		fmt.Fprintln(w, "UNMATCHED BANK TRANSACTIONS")
+		fmt.Fprintln(w, "---------------------------------------------------------")
+		fmt.Fprintln(w, "Transactions in bank statement(s) but missing in system:")
+		fmt.Fprintln(w)
+
+		// Group by bank source
+		banks, byBank := bySource(result.UnmatchedBank)
+		for _, bank := range banks {
+			txns := byBank[bank]
+			fmt.Fprintf(w, "%s (%d transactions):\n", bank, len(txns))
+			for _, txn := range txns {
+				typeStr := "CREDIT"
+				if txn.Type == domain.TransactionTypeDebit {
+					typeStr = "DEBIT"
+				}
+				fmt.Fprintf(w, "ID: %-15s | Type: %-6s | Amount: %10s %s | Date: %s%s\n",
+					txn.ID, typeStr, txn.AbsAmount(), txn.Amount.Currency, txn.TransactionDate.Format("2006-01-02"), textReferences(txn))
+			}
+			fmt.Fprintln(w)
+		}
+	}
+
+	return nil
+}
+
+// writeTextBreakdown writes one line per key with its counts, match rate, unmatched
+// amounts (system / bank) and net difference, skipping empty breakdowns
+func writeTextBreakdown(w io.Writer, title string, breakdowns []matcher.Breakdown) {
+	if len(breakdowns) == 0 {
+		return
+	}
+	fmt.Fprintln(w, title)
+	fmt.Fprintln(w, "---------------------------------------------------------")
+	for _, b := range breakdowns {
+		fmt.Fprintf(w, "%-10s | System: %4d | Bank: %4d | Matched: %5.1f%% | Unmatched: %d / %d | Amount: %s / %s | Net: %s",
+			b.Key, b.SystemTxns, b.BankTxns, b.MatchRate, b.UnmatchedSystem(), b.UnmatchedBank(),
+			b.UnmatchedSystemAmount, b.UnmatchedBankAmount, b.NetDifference)
+		if len(b.UnconvertedAmount) > 0 {
+			fmt.Fprintf(w, " | Unconverted: %s", formatAmounts(b.UnconvertedAmount))
+		}
+		fmt.Fprintln(w)
+	}
+	fmt.Fprintln(w)
+}
+
+// textReferences formats the references of a statement line for the end of a text line
+func textReferences(txn *transaction.Transaction) string {
+	if refs := references(txn); refs != "" {
+		return " | Ref: " + refs
+	}
+	return ""
+}